@@ -3,6 +3,7 @@ package account
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"dario.lol/cf/internal/config"
 	"dario.lol/cf/internal/executor"
@@ -24,7 +25,8 @@ var listCmd = &cobra.Command{
 		WithNoCache().
 		Step(executor.NewStep(accountsKey, "Fetching accounts").
 			Func(fetchAccounts).
-			CacheKey("accounts:list")).
+			CacheKey("accounts:list").
+			CacheTTL(time.Hour)).
 		Display(printAccountsList).
 		Run(),
 }
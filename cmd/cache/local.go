@@ -0,0 +1,247 @@
+package cache
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"dario.lol/cf/internal/config"
+	"dario.lol/cf/internal/db"
+	"dario.lol/cf/internal/executor"
+	"dario.lol/cf/internal/ui"
+	"dario.lol/cf/internal/ui/response"
+	"github.com/spf13/cobra"
+)
+
+const untagged = "(untagged)"
+
+var (
+	localEntriesKey = executor.NewKey[[]db.CacheEntry]("localCacheEntries")
+	localPrunedKey  = executor.NewKey[db.PruneResult]("localCachePruned")
+)
+
+var localCmd = &cobra.Command{
+	Use:   "local",
+	Short: "Inspect and maintain the local response cache",
+	Long:  "Read-only commands cache API responses in ~/.cloudflare-cli/cf.db. Entries are grouped by tags such as zones:list or zone:<id>:dns.",
+}
+
+var localStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show entry count, size and age per tag",
+	Args:  cobra.NoArgs,
+	Run: executor.New().
+		Step(executor.NewStep(localEntriesKey, "Reading cache").Func(readLocalEntries).Silent()).
+		Display(printLocalStats).
+		Run(),
+}
+
+var localListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List cached responses",
+	Args:  cobra.NoArgs,
+	Run: executor.New().
+		Step(executor.NewStep(localEntriesKey, "Reading cache").Func(readLocalEntries).Silent()).
+		Display(printLocalList).
+		Run(),
+}
+
+var localClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Delete cached responses",
+	Example: `  cf cache local clear
+  cf cache local clear --tag zone:`,
+	Args: cobra.NoArgs,
+	Run: executor.New().
+		Step(executor.NewStep(localPrunedKey, "Clearing cache").Func(clearLocalCache).Silent()).
+		Display(printLocalClear).
+		Run(),
+}
+
+var localPruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Delete expired responses and deduplicate tags",
+	Long:  "Delete responses that can no longer be served and tidy up the tag index. Pruning also runs automatically every few hours for responses older than a day.",
+	Args:  cobra.NoArgs,
+	Run: executor.New().
+		Step(executor.NewStep(localPrunedKey, "Pruning cache").Func(pruneLocalCache).Silent()).
+		Display(printLocalPrune).
+		Run(),
+}
+
+func init() {
+	localListCmd.Flags().String("tag", "", "Only list responses with a tag starting with this prefix")
+	localClearCmd.Flags().String("tag", "", "Only delete responses with a tag starting with this prefix")
+
+	localCmd.AddCommand(localStatsCmd)
+	localCmd.AddCommand(localListCmd)
+	localCmd.AddCommand(localClearCmd)
+	localCmd.AddCommand(localPruneCmd)
+	CacheCmd.AddCommand(localCmd)
+}
+
+// entryTTL returns how long the executor serves e after it was stored.
+func entryTTL(e db.CacheEntry) time.Duration {
+	ttl := e.TTL
+	if ttl == 0 {
+		ttl = config.Cfg.CacheTTL
+	}
+	if ttl == 0 {
+		ttl = executor.DefaultCacheTTL
+	}
+	if config.Cfg.StaleWhileRevalidate {
+		ttl = max(ttl, executor.MaxStaleAge)
+	}
+	return ttl
+}
+
+func entryExpired(e db.CacheEntry) bool {
+	return e.Age() > entryTTL(e)
+}
+
+func readLocalEntries(ctx *executor.Context, _ chan<- string) ([]db.CacheEntry, error) {
+	if err := config.LoadConfig(); err != nil && !errors.Is(err, config.ErrNotLoggedIn) {
+		return nil, err
+	}
+	entries, err := db.CacheEntries()
+	if err != nil {
+		return nil, err
+	}
+	prefix, _ := ctx.Cmd.Flags().GetString("tag")
+	if prefix != "" {
+		entries = slices.DeleteFunc(entries, func(e db.CacheEntry) bool {
+			return !slices.ContainsFunc(e.Tags, func(tag string) bool { return strings.HasPrefix(tag, prefix) })
+		})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Timestamp.After(entries[j].Timestamp) })
+	return entries, nil
+}
+
+func clearLocalCache(ctx *executor.Context, _ chan<- string) (db.PruneResult, error) {
+	prefix, _ := ctx.Cmd.Flags().GetString("tag")
+	if prefix != "" {
+		return db.InvalidateTagPrefix(prefix)
+	}
+	return db.ClearCache()
+}
+
+func pruneLocalCache(_ *executor.Context, _ chan<- string) (db.PruneResult, error) {
+	if err := config.LoadConfig(); err != nil && !errors.Is(err, config.ErrNotLoggedIn) {
+		return db.PruneResult{}, err
+	}
+	return db.PruneCache(entryExpired)
+}
+
+func printLocalStats(ctx *executor.Context) {
+	rb := response.New()
+	if ctx.Error != nil {
+		rb.Error("Error reading cache", ctx.Error).Display()
+		return
+	}
+
+	entries := executor.Get(ctx, localEntriesKey)
+	if len(entries) == 0 {
+		rb.FooterSuccessf("The local cache is empty").Display()
+		return
+	}
+
+	byTag := make(map[string][]db.CacheEntry)
+	var totalSize, expired int
+	for _, e := range entries {
+		totalSize += e.Size
+		if entryExpired(e) {
+			expired++
+		}
+		tags := e.Tags
+		if len(tags) == 0 {
+			tags = []string{untagged}
+		}
+		for _, tag := range tags {
+			byTag[tag] = append(byTag[tag], e)
+		}
+	}
+
+	tags := make([]string, 0, len(byTag))
+	for tag := range byTag {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+
+	for _, tag := range tags {
+		tagged := byTag[tag]
+		var size int
+		oldest, newest := tagged[0].Age(), tagged[0].Age()
+		for _, e := range tagged {
+			size += e.Size
+			oldest = max(oldest, e.Age())
+			newest = min(newest, e.Age())
+		}
+		rb.AddItem(tag, response.NewItemContent().
+			Add("Entries:", ui.Text(fmt.Sprintf("%d", len(tagged)))).
+			Add("Size:", ui.Text(ui.FormatBytes(int64(size)))).
+			Add("Newest:", ui.Text(ui.FormatAge(newest)+" ago")).
+			Add("Oldest:", ui.Text(ui.FormatAge(oldest)+" ago")).
+			String())
+	}
+
+	if expired > 0 {
+		rb.FooterSuccessf("%d cached response(s) using %s, %d expired %s", len(entries), ui.FormatBytes(int64(totalSize)), expired, ui.Muted("(prune with cf cache local prune)")).Display()
+		return
+	}
+	rb.FooterSuccessf("%d cached response(s) using %s", len(entries), ui.FormatBytes(int64(totalSize))).Display()
+}
+
+func printLocalList(ctx *executor.Context) {
+	rb := response.New()
+	if ctx.Error != nil {
+		rb.Error("Error reading cache", ctx.Error).Display()
+		return
+	}
+
+	entries := executor.Get(ctx, localEntriesKey)
+	if len(entries) == 0 {
+		rb.FooterSuccessf("No cached responses found").Display()
+		return
+	}
+
+	for _, e := range entries {
+		title := untagged
+		if len(e.Tags) > 0 {
+			title = strings.Join(e.Tags, ", ")
+		}
+		state := "expires in " + ui.FormatAge(entryTTL(e)-e.Age())
+		if entryExpired(e) {
+			state = ui.Muted("expired")
+		}
+		rb.AddItem(title, response.NewItemContent().
+			Add("Key:", ui.Text(e.Key)).
+			Add("Size:", ui.Text(ui.FormatBytes(int64(e.Size)))).
+			Add("Cached:", ui.Text(fmt.Sprintf("%s ago, %s", ui.FormatAge(e.Age()), state))).
+			String())
+	}
+
+	rb.FooterSuccessf("Found %d cached response(s)", len(entries)).Display()
+}
+
+func printLocalClear(ctx *executor.Context) {
+	rb := response.New()
+	if ctx.Error != nil {
+		rb.Error("Error clearing cache", ctx.Error).Display()
+		return
+	}
+	result := executor.Get(ctx, localPrunedKey)
+	rb.FooterSuccessf("Deleted %d cached response(s) %s", result.Entries, ui.Muted(fmt.Sprintf("(%s freed)", ui.FormatBytes(int64(result.Bytes))))).Display()
+}
+
+func printLocalPrune(ctx *executor.Context) {
+	rb := response.New()
+	if ctx.Error != nil {
+		rb.Error("Error pruning cache", ctx.Error).Display()
+		return
+	}
+	result := executor.Get(ctx, localPrunedKey)
+	rb.FooterSuccessf("Pruned %d expired response(s), %d empty tag(s) and %d stale upload record(s) %s", result.Entries, result.Tags, result.Uploads, ui.Muted(fmt.Sprintf("(%s freed)", ui.FormatBytes(int64(result.Bytes))))).Display()
+}
@@ -0,0 +1,228 @@
+package cache
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"dario.lol/cf/internal/cloudflare"
+	"dario.lol/cf/internal/executor"
+	"dario.lol/cf/internal/ui"
+	"dario.lol/cf/internal/ui/response"
+	cf "github.com/cloudflare/cloudflare-go/v6"
+	"github.com/cloudflare/cloudflare-go/v6/cache"
+	"github.com/cloudflare/cloudflare-go/v6/option"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// defaultPurgeBatchSize is the number of files, tags, hosts or prefixes
+	// every plan accepts in a single purge request.
+	defaultPurgeBatchSize = 30
+	maxPurgeAttempts      = 5
+)
+
+type PurgeResult struct {
+	Zones    []string
+	Items    int
+	Requests int
+	Retries  int
+}
+
+// purgeBatch is a single purge request for one zone.
+type purgeBatch struct {
+	zoneID   string
+	zoneName string
+	kind     string
+	values   []string
+}
+
+var purgeResultKey = executor.NewKey[*PurgeResult]("purgeResult")
+
+var cachePurgeCmd = &cobra.Command{
+	Use:   "purge",
+	Short: "Purges the Cloudflare cache",
+	Long:  "Purge cached content by URL, cache tag, hostname or URL prefix, or everything. Long lists are split into batches the API accepts and rate limited requests are retried.",
+	Example: `  cf cache purge --zone example.com --all
+  cf cache purge --zone example.com --files https://example.com/app.js
+  cf cache purge --zone example.com --files-from urls.txt
+  cf cache purge --zone example.com --zone example.org --prefixes example.com/assets/
+  cf cache purge --zone example.com --hosts static.example.com --tags release-42`,
+	Run: executor.New().
+		WithClient().
+		Step(executor.NewStep(purgeResultKey, "Purging cache").Func(purgeCache)).
+		Display(printPurgeResult).
+		Run(),
+}
+
+func init() {
+	cachePurgeCmd.Flags().StringSlice("zone", []string{}, "The zone(s) to purge the cache for")
+	cachePurgeCmd.Flags().Bool("all", false, "Purge all files")
+	cachePurgeCmd.Flags().StringSlice("files", []string{}, "A list of files to purge")
+	cachePurgeCmd.Flags().String("files-from", "", "Read URLs to purge from a file, one per line (- for stdin)")
+	cachePurgeCmd.Flags().StringSlice("tags", []string{}, "A list of tags to purge")
+	cachePurgeCmd.Flags().StringSlice("hosts", []string{}, "A list of hostnames to purge")
+	cachePurgeCmd.Flags().StringSlice("prefixes", []string{}, "A list of URL prefixes to purge, e.g. example.com/assets/")
+	cachePurgeCmd.Flags().Int("batch-size", defaultPurgeBatchSize, "Items per purge request (Enterprise zones accept up to 500)")
+	cachePurgeCmd.MarkFlagsMutuallyExclusive("all", "files")
+	cachePurgeCmd.MarkFlagsMutuallyExclusive("all", "files-from")
+	cachePurgeCmd.MarkFlagsMutuallyExclusive("all", "tags")
+	cachePurgeCmd.MarkFlagsMutuallyExclusive("all", "hosts")
+	cachePurgeCmd.MarkFlagsMutuallyExclusive("all", "prefixes")
+	CacheCmd.AddCommand(cachePurgeCmd)
+}
+
+// readURLList reads one URL per line, skipping blank lines and # comments.
+func readURLList(path string) ([]string, error) {
+	var r io.Reader = os.Stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	var urls []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		urls = append(urls, line)
+	}
+	return urls, scanner.Err()
+}
+
+func purgeBody(kind string, values []string) cache.CachePurgeParamsBodyUnion {
+	switch kind {
+	case "files":
+		return &cache.CachePurgeParamsBodyCachePurgeSingleFile{Files: cf.F(values)}
+	case "tags":
+		return &cache.CachePurgeParamsBodyCachePurgeFlexPurgeByTags{Tags: cf.F(values)}
+	case "hosts":
+		return &cache.CachePurgeParamsBodyCachePurgeFlexPurgeByHostnames{Hosts: cf.F(values)}
+	case "prefixes":
+		return &cache.CachePurgeParamsBodyCachePurgeFlexPurgeByPrefixes{Prefixes: cf.F(values)}
+	default:
+		return &cache.CachePurgeParamsBodyCachePurgeEverything{PurgeEverything: cf.F(true)}
+	}
+}
+
+// retryAfter returns how long to wait before retrying a rate limited
+// request, or false when err is not a rate limit.
+func retryAfter(err error, attempt int) (time.Duration, bool) {
+	var apiErr *cf.Error
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusTooManyRequests {
+		return 0, false
+	}
+	if apiErr.Response != nil {
+		if secs, err := strconv.Atoi(apiErr.Response.Header.Get("Retry-After")); err == nil && secs > 0 {
+			return time.Duration(secs) * time.Second, true
+		}
+	}
+	return min(time.Duration(1<<attempt)*time.Second, time.Minute), true
+}
+
+func purgeCache(ctx *executor.Context, progress chan<- string) (*PurgeResult, error) {
+	zoneIdentifiers, _ := ctx.Cmd.Flags().GetStringSlice("zone")
+	all, _ := ctx.Cmd.Flags().GetBool("all")
+	batchSize, _ := ctx.Cmd.Flags().GetInt("batch-size")
+
+	if len(zoneIdentifiers) == 0 {
+		return nil, fmt.Errorf("the --zone flag is required")
+	}
+	if batchSize <= 0 {
+		return nil, fmt.Errorf("--batch-size must be positive")
+	}
+
+	lists := make(map[string][]string)
+	for _, kind := range []string{"files", "tags", "hosts", "prefixes"} {
+		lists[kind], _ = ctx.Cmd.Flags().GetStringSlice(kind)
+	}
+	if path, _ := ctx.Cmd.Flags().GetString("files-from"); path != "" {
+		urls, err := readURLList(path)
+		if err != nil {
+			return nil, fmt.Errorf("error reading %s: %w", path, err)
+		}
+		lists["files"] = append(lists["files"], urls...)
+	}
+	if !all && len(lists["files"])+len(lists["tags"])+len(lists["hosts"])+len(lists["prefixes"]) == 0 {
+		return nil, fmt.Errorf("please specify what to purge with --all, --files, --files-from, --tags, --hosts or --prefixes")
+	}
+
+	result := &PurgeResult{}
+	var batches []purgeBatch
+	for _, identifier := range zoneIdentifiers {
+		zoneID, zoneName, err := cloudflare.LookupZone(ctx.Client, identifier)
+		if err != nil {
+			return nil, fmt.Errorf("error finding zone %s: %w", identifier, err)
+		}
+		result.Zones = append(result.Zones, zoneName)
+		if all {
+			batches = append(batches, purgeBatch{zoneID: zoneID, zoneName: zoneName, kind: "all"})
+			continue
+		}
+		for _, kind := range []string{"files", "tags", "hosts", "prefixes"} {
+			values := lists[kind]
+			for start := 0; start < len(values); start += batchSize {
+				end := min(start+batchSize, len(values))
+				batches = append(batches, purgeBatch{zoneID: zoneID, zoneName: zoneName, kind: kind, values: values[start:end]})
+			}
+		}
+	}
+
+	for i, batch := range batches {
+		progress <- fmt.Sprintf("Purging %s %s", batch.zoneName, ui.ProgressBar(i, len(batches), 20))
+		for attempt := 1; ; attempt++ {
+			_, err := ctx.Client.Cache.Purge(context.Background(), cache.CachePurgeParams{
+				ZoneID: cf.F(batch.zoneID),
+				Body:   purgeBody(batch.kind, batch.values),
+			}, option.WithMaxRetries(0))
+			if err == nil {
+				break
+			}
+			wait, limited := retryAfter(err, attempt)
+			if !limited || attempt == maxPurgeAttempts {
+				return nil, fmt.Errorf("purging %s of %s failed at request %d/%d (%d item(s) purged): %w", batch.kind, batch.zoneName, i+1, len(batches), result.Items, err)
+			}
+			result.Retries++
+			progress <- fmt.Sprintf("Rate limited purging %s, retrying in %v %s", batch.zoneName, wait, ui.ProgressBar(i, len(batches), 20))
+			time.Sleep(wait)
+		}
+		result.Requests++
+		result.Items += len(batch.values)
+	}
+	progress <- fmt.Sprintf("Purging cache %s", ui.ProgressBar(len(batches), len(batches), 20))
+
+	return result, nil
+}
+
+func printPurgeResult(ctx *executor.Context) {
+	rb := response.New()
+	if ctx.Error != nil {
+		rb.Error("Error purging cache", ctx.Error).Display()
+		return
+	}
+
+	result := executor.Get(ctx, purgeResultKey)
+	zones := strings.Join(result.Zones, ", ")
+	details := fmt.Sprintf("(%d request(s), took %v)", result.Requests, ctx.Duration)
+	if result.Retries > 0 {
+		details = fmt.Sprintf("(%d request(s), %d rate limited, took %v)", result.Requests, result.Retries, ctx.Duration)
+	}
+	if result.Items == 0 {
+		rb.FooterSuccessf("Successfully purged everything in %s %s", zones, ui.Muted(details)).Display()
+		return
+	}
+	rb.FooterSuccessf("Successfully purged %d item(s) in %s %s", result.Items, zones, ui.Muted(details)).Display()
+}
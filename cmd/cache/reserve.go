@@ -0,0 +1,86 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"dario.lol/cf/internal/executor"
+	"dario.lol/cf/internal/ui"
+	"dario.lol/cf/internal/ui/response"
+	cf "github.com/cloudflare/cloudflare-go/v6"
+	"github.com/cloudflare/cloudflare-go/v6/cache"
+	"github.com/spf13/cobra"
+)
+
+var reserveValueKey = executor.NewKey[string]("cacheReserveValue")
+
+var reserveCmd = &cobra.Command{
+	Use:   "reserve",
+	Short: "Manage Cache Reserve",
+	Long:  "Cache Reserve keeps cacheable content in R2 so it is served from cache even after it is evicted at the edge. It is a paid add-on and requires Tiered Cache.",
+}
+
+var reserveGetCmd = &cobra.Command{
+	Use:   "get <zone>",
+	Short: "Get whether Cache Reserve is enabled",
+	Args:  cobra.ExactArgs(1),
+	Run: executor.New().
+		WithClient().
+		WithZone().
+		Step(executor.NewStep(reserveValueKey, "Fetching Cache Reserve").Func(getCacheReserve)).
+		Display(printCacheReserve).
+		Run(),
+}
+
+var reserveSetCmd = &cobra.Command{
+	Use:   "set <zone> <on|off>",
+	Short: "Enable or disable Cache Reserve",
+	Args:  cobra.ExactArgs(2),
+	Run: executor.New().
+		WithClient().
+		WithZone().
+		Step(executor.NewStep(reserveValueKey, "Updating Cache Reserve").Func(setCacheReserve)).
+		Display(printCacheReserve).
+		Run(),
+}
+
+func init() {
+	reserveCmd.AddCommand(reserveGetCmd)
+	reserveCmd.AddCommand(reserveSetCmd)
+	CacheCmd.AddCommand(reserveCmd)
+}
+
+func getCacheReserve(ctx *executor.Context, _ chan<- string) (string, error) {
+	res, err := ctx.Client.Cache.CacheReserve.Get(context.Background(), cache.CacheReserveGetParams{
+		ZoneID: cf.F(executor.Get(ctx, executor.ZoneIDKey)),
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(res.Value), nil
+}
+
+func setCacheReserve(ctx *executor.Context, _ chan<- string) (string, error) {
+	value := ctx.Args[1]
+	if !slices.Contains([]string{"on", "off"}, value) {
+		return "", fmt.Errorf("invalid value: %s. valid values are: [on off]", value)
+	}
+	res, err := ctx.Client.Cache.CacheReserve.Edit(context.Background(), cache.CacheReserveEditParams{
+		ZoneID: cf.F(executor.Get(ctx, executor.ZoneIDKey)),
+		Value:  cf.F(cache.CacheReserveEditParamsValue(value)),
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(res.Value), nil
+}
+
+func printCacheReserve(ctx *executor.Context) {
+	rb := response.New()
+	if ctx.Error != nil {
+		rb.Error("Error managing Cache Reserve", ctx.Error).Display()
+		return
+	}
+	rb.FooterSuccessf("Cache Reserve for %s is %s %s", executor.Get(ctx, executor.ZoneNameKey), ui.Code.Render(executor.Get(ctx, reserveValueKey)), ui.Muted(fmt.Sprintf("(took %v)", ctx.Duration))).Display()
+}
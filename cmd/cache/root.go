@@ -0,0 +1,10 @@
+package cache
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var CacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Manage Cloudflare cache",
+}
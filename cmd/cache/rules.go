@@ -0,0 +1,211 @@
+package cache
+
+import (
+	"fmt"
+	"time"
+
+	"dario.lol/cf/internal/cloudflare"
+	"dario.lol/cf/internal/executor"
+	"dario.lol/cf/internal/flags"
+	"dario.lol/cf/internal/ui"
+	"dario.lol/cf/internal/ui/response"
+	"github.com/spf13/cobra"
+)
+
+var cacheRulesPhase = cloudflare.ZonePhase{Name: "http_request_cache_settings", Command: "cache-rules"}
+
+var (
+	cacheRulesetKey = executor.NewKey[*cloudflare.Ruleset]("cacheRuleset")
+	cacheRuleKey    = executor.NewKey[*cloudflare.Rule]("cacheRule")
+)
+
+var rulesCmd = &cobra.Command{
+	Use:   "rules",
+	Short: "Manage cache rules",
+	Long:  "Cache rules decide whether and for how long matching requests are cached. They live in the zone's http_request_cache_settings ruleset and are evaluated in order.",
+}
+
+var rulesListCmd = &cobra.Command{
+	Use:   "list <zone>",
+	Short: "List cache rules",
+	Args:  cobra.ExactArgs(1),
+	Run: executor.New().
+		WithClient().
+		WithZone().
+		WithNoCache().
+		Step(executor.NewStep(cacheRulesetKey, "Fetching cache rules").
+			Func(executor.PhaseRules(cacheRulesPhase)).
+			CacheKeyFunc(executor.PhaseCacheKey(cacheRulesPhase))).
+		Display(printCacheRules).
+		Run(),
+}
+
+var rulesCreateCmd = &cobra.Command{
+	Use:   "create <zone>",
+	Short: "Create a cache rule",
+	Example: `  cf cache rules create example.com --expression 'starts_with(http.request.uri.path, "/assets/")' --edge-ttl 24h --browser-ttl 1h --description "Cache assets"
+  cf cache rules create example.com --expression 'http.request.uri.path eq "/api"' --bypass`,
+	Args: cobra.ExactArgs(1),
+	Run: executor.New().
+		WithClient().
+		WithZone().
+		Step(executor.NewStep(cacheRulesetKey, "Creating cache rule").Func(createCacheRule)).
+		Invalidates(executor.PhaseTags(cacheRulesPhase)).
+		Display(printCreateCacheRule).
+		Run(),
+}
+
+var rulesDeleteCmd = &cobra.Command{
+	Use:   "delete <zone> <rule>",
+	Short: "Delete a cache rule by ID or description",
+	Args:  cobra.ExactArgs(2),
+	Run: executor.New().
+		WithClient().
+		WithZone().
+		Step(executor.NewStep(cacheRuleKey, "Fetching cache rules").Func(executor.PhaseRule(cacheRulesPhase, cacheRulesetKey))).
+		WithConfirmationFunc(func(ctx *executor.Context) string {
+			return fmt.Sprintf("Are you sure you want to delete cache rule %s in zone %s?", executor.Get(ctx, cacheRuleKey).Label(), executor.Get(ctx, executor.ZoneNameKey))
+		}).
+		Step(executor.NewStep(cacheRulesetKey, "Deleting cache rule").Func(deleteCacheRule)).
+		Invalidates(executor.PhaseTags(cacheRulesPhase)).
+		Display(printDeleteCacheRule).
+		Run(),
+}
+
+func init() {
+	rulesListCmd.Flags().Bool("no-cache", false, "Bypass the cache and fetch directly from the API")
+
+	rulesCreateCmd.Flags().String("expression", "", "Rule expression matching the requests to cache")
+	rulesCreateCmd.Flags().String("description", "", "Description of the rule")
+	rulesCreateCmd.Flags().Bool("bypass", false, "Bypass the cache for matching requests")
+	rulesCreateCmd.Flags().Duration("edge-ttl", 0, "Cache at the edge for this long, ignoring origin headers")
+	rulesCreateCmd.Flags().Duration("browser-ttl", 0, "Tell browsers to cache for this long, ignoring origin headers")
+	_ = rulesCreateCmd.MarkFlagRequired("expression")
+	rulesCreateCmd.MarkFlagsMutuallyExclusive("bypass", "edge-ttl")
+	rulesCreateCmd.MarkFlagsMutuallyExclusive("bypass", "browser-ttl")
+
+	flags.RegisterConfirmation(rulesDeleteCmd)
+
+	rulesCmd.AddCommand(rulesListCmd)
+	rulesCmd.AddCommand(rulesCreateCmd)
+	rulesCmd.AddCommand(rulesDeleteCmd)
+	CacheCmd.AddCommand(rulesCmd)
+}
+
+// ttlParameter builds an edge_ttl or browser_ttl action parameter that
+// overrides the origin's cache headers.
+func ttlParameter(ttl time.Duration) map[string]any {
+	return map[string]any{"mode": "override_origin", "default": int(ttl.Seconds())}
+}
+
+func createCacheRule(ctx *executor.Context, _ chan<- string) (*cloudflare.Ruleset, error) {
+	expression, _ := ctx.Cmd.Flags().GetString("expression")
+	description, _ := ctx.Cmd.Flags().GetString("description")
+	bypass, _ := ctx.Cmd.Flags().GetBool("bypass")
+	edgeTTL, _ := ctx.Cmd.Flags().GetDuration("edge-ttl")
+	browserTTL, _ := ctx.Cmd.Flags().GetDuration("browser-ttl")
+
+	params := map[string]any{"cache": !bypass}
+	if edgeTTL > 0 {
+		params["edge_ttl"] = ttlParameter(edgeTTL)
+	}
+	if browserTTL > 0 {
+		params["browser_ttl"] = ttlParameter(browserTTL)
+	}
+
+	ruleset, _, err := cacheRulesPhase.AddRule(ctx.Client, executor.Get(ctx, executor.ZoneIDKey), cloudflare.Rule{
+		Expression:       expression,
+		Action:           "set_cache_settings",
+		ActionParameters: params,
+		Description:      description,
+	}, cloudflare.RulePosition{})
+	return ruleset, err
+}
+
+func deleteCacheRule(ctx *executor.Context, _ chan<- string) (*cloudflare.Ruleset, error) {
+	ruleset := executor.Get(ctx, cacheRulesetKey)
+	return cloudflare.DeleteRule(ctx.Client, cloudflare.ZoneScope(executor.Get(ctx, executor.ZoneIDKey)), ruleset.ID, executor.Get(ctx, cacheRuleKey).ID)
+}
+
+// ttlSummary describes an edge_ttl or browser_ttl action parameter.
+func ttlSummary(param any) string {
+	settings, ok := param.(map[string]any)
+	if !ok {
+		return ""
+	}
+	switch settings["mode"] {
+	case "override_origin":
+		if secs, ok := settings["default"].(float64); ok {
+			return (time.Duration(secs) * time.Second).String()
+		}
+		return "override origin"
+	case "bypass_by_default":
+		return "bypass unless the origin allows caching"
+	case "respect_origin":
+		return "respect origin"
+	}
+	return ""
+}
+
+func printCacheRules(ctx *executor.Context) {
+	rb := response.New()
+	if ctx.Error != nil {
+		rb.Error("Error fetching cache rules", ctx.Error).Display()
+		return
+	}
+
+	ruleset := executor.Get(ctx, cacheRulesetKey)
+	zoneName := executor.Get(ctx, executor.ZoneNameKey)
+	if len(ruleset.Rules) == 0 {
+		rb.FooterSuccessf("No cache rules found for %s", zoneName).Display()
+		return
+	}
+
+	for i, rule := range ruleset.Rules {
+		caching := "eligible"
+		if eligible, ok := rule.ActionParameters["cache"].(bool); ok && !eligible {
+			caching = "bypass"
+		}
+		icb := response.NewItemContent().
+			Add("ID:", ui.Text(rule.ID)).
+			Add("Expression:", ui.Text(rule.Expression)).
+			Add("Cache:", ui.Text(caching))
+		if ttl := ttlSummary(rule.ActionParameters["edge_ttl"]); ttl != "" {
+			icb.Add("Edge TTL:", ui.Text(ttl))
+		}
+		if ttl := ttlSummary(rule.ActionParameters["browser_ttl"]); ttl != "" {
+			icb.Add("Browser TTL:", ui.Text(ttl))
+		}
+		if !rule.IsEnabled() {
+			icb.Add("Enabled:", ui.Muted("no"))
+		}
+
+		title := rule.Description
+		if title == "" {
+			title = fmt.Sprintf("Rule %d", i+1)
+		}
+		rb.AddItem(title, icb.String())
+	}
+
+	rb.FooterSuccessf("Found %d cache rule(s) for %s %s", len(ruleset.Rules), zoneName, ui.Muted(fmt.Sprintf("(took %v)", ctx.Duration))).Display()
+}
+
+func printCreateCacheRule(ctx *executor.Context) {
+	rb := response.New()
+	if ctx.Error != nil {
+		rb.Error("Error creating cache rule", ctx.Error).Display()
+		return
+	}
+	ruleset := executor.Get(ctx, cacheRulesetKey)
+	rule := ruleset.Rules[len(ruleset.Rules)-1]
+	rb.FooterSuccessf("Created cache rule %s in %s %s", rule.ID, executor.Get(ctx, executor.ZoneNameKey), ui.Muted(fmt.Sprintf("(took %v)", ctx.Duration))).Display()
+}
+
+func printDeleteCacheRule(ctx *executor.Context) {
+	rb := response.New()
+	if ctx.Error != nil {
+		rb.Error("Error deleting cache rule", ctx.Error).Display()
+		return
+	}
+	rb.FooterSuccessf("Deleted cache rule %s from %s %s", executor.Get(ctx, cacheRuleKey).Label(), executor.Get(ctx, executor.ZoneNameKey), ui.Muted(fmt.Sprintf("(took %v)", ctx.Duration))).Display()
+}
@@ -0,0 +1,133 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"dario.lol/cf/internal/executor"
+	"dario.lol/cf/internal/ui"
+	"dario.lol/cf/internal/ui/response"
+	cf "github.com/cloudflare/cloudflare-go/v6"
+	"github.com/cloudflare/cloudflare-go/v6/argo"
+	"github.com/cloudflare/cloudflare-go/v6/cache"
+	"github.com/spf13/cobra"
+)
+
+var tieredModes = []string{"off", "generic", "smart"}
+
+var tieredModeKey = executor.NewKey[string]("tieredCacheMode")
+
+var tieredCmd = &cobra.Command{
+	Use:   "tiered",
+	Short: "Manage Tiered Cache",
+	Long:  "Tiered Cache lets edge data centers fetch content from upper-tier data centers instead of your origin. generic uses all data centers as upper tiers, smart picks the ones closest to your origin.",
+}
+
+var tieredGetCmd = &cobra.Command{
+	Use:   "get <zone>",
+	Short: "Get the Tiered Cache topology",
+	Args:  cobra.ExactArgs(1),
+	Run: executor.New().
+		WithClient().
+		WithZone().
+		Step(executor.NewStep(tieredModeKey, "Fetching Tiered Cache").Func(getTieredMode)).
+		Display(printTieredMode).
+		Run(),
+}
+
+var tieredSetCmd = &cobra.Command{
+	Use:   "set <zone> <mode>",
+	Short: "Set the Tiered Cache topology (off, generic, smart)",
+	Args:  cobra.ExactArgs(2),
+	Run: executor.New().
+		WithClient().
+		WithZone().
+		Step(executor.NewStep(tieredModeKey, "Updating Tiered Cache").Func(setTieredMode)).
+		Display(printTieredMode).
+		Run(),
+}
+
+func init() {
+	tieredCmd.AddCommand(tieredGetCmd)
+	tieredCmd.AddCommand(tieredSetCmd)
+	CacheCmd.AddCommand(tieredCmd)
+}
+
+func getTieredMode(ctx *executor.Context, _ chan<- string) (string, error) {
+	zoneID := executor.Get(ctx, executor.ZoneIDKey)
+	tiered, err := ctx.Client.Argo.TieredCaching.Get(context.Background(), argo.TieredCachingGetParams{
+		ZoneID: cf.F(zoneID),
+	})
+	if err != nil {
+		return "", err
+	}
+	if tiered.Value != argo.TieredCachingGetResponseValueOn {
+		return "off", nil
+	}
+	smart, err := ctx.Client.Cache.SmartTieredCache.Get(context.Background(), cache.SmartTieredCacheGetParams{
+		ZoneID: cf.F(zoneID),
+	})
+	if err != nil {
+		return "", err
+	}
+	if smart.Value == cache.SmartTieredCacheGetResponseValueOn {
+		return "smart", nil
+	}
+	return "generic", nil
+}
+
+// setTieredMode switches Tiered Cache on before enabling the smart topology
+// and turns the smart topology off before Tiered Cache itself.
+func setTieredMode(ctx *executor.Context, progress chan<- string) (string, error) {
+	mode := ctx.Args[1]
+	if !slices.Contains(tieredModes, mode) {
+		return "", fmt.Errorf("invalid tiered cache mode: %s. valid modes are: %v", mode, tieredModes)
+	}
+
+	zoneID := executor.Get(ctx, executor.ZoneIDKey)
+	tiered := argo.TieredCachingEditParamsValueOn
+	smart := cache.SmartTieredCacheEditParamsValueOff
+	switch mode {
+	case "off":
+		tiered = argo.TieredCachingEditParamsValueOff
+	case "smart":
+		smart = cache.SmartTieredCacheEditParamsValueOn
+	}
+
+	editTiered := func() error {
+		_, err := ctx.Client.Argo.TieredCaching.Edit(context.Background(), argo.TieredCachingEditParams{
+			ZoneID: cf.F(zoneID),
+			Value:  cf.F(tiered),
+		})
+		return err
+	}
+	editSmart := func() error {
+		progress <- "Updating smart topology"
+		_, err := ctx.Client.Cache.SmartTieredCache.Edit(context.Background(), cache.SmartTieredCacheEditParams{
+			ZoneID: cf.F(zoneID),
+			Value:  cf.F(smart),
+		})
+		return err
+	}
+
+	steps := []func() error{editTiered, editSmart}
+	if mode == "off" {
+		steps = []func() error{editSmart, editTiered}
+	}
+	for _, step := range steps {
+		if err := step(); err != nil {
+			return "", err
+		}
+	}
+	return mode, nil
+}
+
+func printTieredMode(ctx *executor.Context) {
+	rb := response.New()
+	if ctx.Error != nil {
+		rb.Error("Error managing Tiered Cache", ctx.Error).Display()
+		return
+	}
+	rb.FooterSuccessf("Tiered Cache for %s is %s %s", executor.Get(ctx, executor.ZoneNameKey), ui.Code.Render(executor.Get(ctx, tieredModeKey)), ui.Muted(fmt.Sprintf("(took %v)", ctx.Duration))).Display()
+}
@@ -3,8 +3,10 @@ package cmd
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"dario.lol/cf/internal/config"
+	"dario.lol/cf/internal/executor"
 	"dario.lol/cf/internal/ui"
 	"dario.lol/cf/internal/ui/response"
 	"github.com/spf13/cobra"
@@ -17,7 +19,7 @@ var configCmd = &cobra.Command{
 
 var configSetCmd = &cobra.Command{
 	Use:   "set <key> <value>",
-	Short: "Set a configuration value (e.g., caching true|false)",
+	Short: "Set a configuration value (caching true|false, cache_ttl <duration>|default, stale_while_revalidate true|false)",
 	Args:  cobra.ExactArgs(2),
 	Run: func(cmd *cobra.Command, args []string) {
 		key := strings.ToLower(args[0])
@@ -44,6 +46,36 @@ var configSetCmd = &cobra.Command{
 				return
 			}
 			rb.FooterSuccessf("Configuration updated: %s set to %v", ui.Code.Render(key), config.Cfg.Caching).Display()
+		case "cache_ttl":
+			if value == "0" || value == "default" {
+				config.Cfg.CacheTTL = 0
+			} else {
+				ttl, err := time.ParseDuration(value)
+				if err != nil || ttl <= 0 {
+					rb.Error("Invalid value", fmt.Errorf("cache_ttl must be a positive duration such as 10m, or default")).Display()
+					return
+				}
+				config.Cfg.CacheTTL = ttl
+			}
+			if err := config.SaveConfig(); err != nil {
+				rb.Error("Failed to save config", err).Display()
+				return
+			}
+			rb.FooterSuccessf("Configuration updated: %s set to %s", ui.Code.Render(key), cacheTTLString()).Display()
+		case "stale_while_revalidate":
+			if value == "true" || value == "1" || value == "on" {
+				config.Cfg.StaleWhileRevalidate = true
+			} else if value == "false" || value == "0" || value == "off" {
+				config.Cfg.StaleWhileRevalidate = false
+			} else {
+				rb.Error("Invalid value", fmt.Errorf("stale_while_revalidate must be true or false")).Display()
+				return
+			}
+			if err := config.SaveConfig(); err != nil {
+				rb.Error("Failed to save config", err).Display()
+				return
+			}
+			rb.FooterSuccessf("Configuration updated: %s set to %v", ui.Code.Render(key), config.Cfg.StaleWhileRevalidate).Display()
 		default:
 			rb.Error("Unknown configuration key", fmt.Errorf("key %q is not supported", key)).Display()
 		}
@@ -61,12 +93,23 @@ var configGetCmd = &cobra.Command{
 		switch key {
 		case "caching":
 			rb.FooterSuccessf("Current %s setting: %v", ui.Code.Render(key), config.Cfg.Caching).Display()
+		case "cache_ttl":
+			rb.FooterSuccessf("Current %s setting: %s", ui.Code.Render(key), cacheTTLString()).Display()
+		case "stale_while_revalidate":
+			rb.FooterSuccessf("Current %s setting: %v", ui.Code.Render(key), config.Cfg.StaleWhileRevalidate).Display()
 		default:
 			rb.Error("Unknown configuration key", fmt.Errorf("key %q is not supported", key)).Display()
 		}
 	},
 }
 
+func cacheTTLString() string {
+	if config.Cfg.CacheTTL == 0 {
+		return fmt.Sprintf("default (%v)", executor.DefaultCacheTTL)
+	}
+	return config.Cfg.CacheTTL.String()
+}
+
 func init() {
 	configCmd.AddCommand(configSetCmd)
 	configCmd.AddCommand(configGetCmd)
@@ -4,11 +4,11 @@ import (
 	"context"
 	"fmt"
 
+	"dario.lol/cf/internal/cloudflare"
 	"dario.lol/cf/internal/executor"
 	"dario.lol/cf/internal/ui"
 	"dario.lol/cf/internal/ui/response"
 	cf "github.com/cloudflare/cloudflare-go/v6"
-	"github.com/cloudflare/cloudflare-go/v6/d1"
 	"github.com/cloudflare/cloudflare-go/v6/pages"
 	"github.com/spf13/cobra"
 )
@@ -22,6 +22,7 @@ var bindCmd = &cobra.Command{
 	Run: executor.New().
 		WithClient().
 		WithAccountID().
+		WithD1Database().
 		Step(executor.NewStep(boundD1ProjectKey, "Binding database").Func(bindDatabase)).
 		Display(printD1BindResult).
 		Run(),
@@ -35,27 +36,14 @@ func init() {
 }
 
 func bindDatabase(ctx *executor.Context, _ chan<- string) (*pages.Project, error) {
-	dbName := ctx.Args[0]
 	bindToProject, _ := ctx.Cmd.Flags().GetString("to")
 	bindBindingName, _ := ctx.Cmd.Flags().GetString("name")
 
-	pager := ctx.Client.D1.Database.ListAutoPaging(context.Background(), d1.DatabaseListParams{
-		AccountID: cf.F(ctx.AccountID),
-	})
+	dbID := executor.Get(ctx, executor.D1DatabaseIDKey)
 
-	var dbID string
-	for pager.Next() {
-		db := pager.Current()
-		if db.Name == dbName || db.UUID == dbName {
-			dbID = db.UUID
-			break
-		}
-	}
-	if err := pager.Err(); err != nil {
-		return nil, fmt.Errorf("error listing databases: %w", err)
-	}
-	if dbID == "" {
-		return nil, fmt.Errorf("database '%s' not found", dbName)
+	_, bindToProject, err := cloudflare.LookupPagesProject(ctx.Client, ctx.AccountID, bindToProject)
+	if err != nil {
+		return nil, err
 	}
 
 	proj, err := ctx.Client.Pages.Projects.Get(context.Background(), bindToProject, pages.ProjectGetParams{
@@ -3,21 +3,33 @@ package d1
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 
 	"dario.lol/cf/internal/executor"
 	"dario.lol/cf/internal/ui"
 	"dario.lol/cf/internal/ui/response"
 	cf "github.com/cloudflare/cloudflare-go/v6"
 	"github.com/cloudflare/cloudflare-go/v6/d1"
+	"github.com/cloudflare/cloudflare-go/v6/option"
 	"github.com/spf13/cobra"
 )
 
+var (
+	databaseLocations     = []string{"wnam", "enam", "weur", "eeur", "apac", "oc"}
+	databaseJurisdictions = []string{"eu", "fedramp"}
+)
+
 var createdDatabaseKey = executor.NewKey[*d1.D1]("createdDatabase")
 
 var createCmd = &cobra.Command{
 	Use:   "create <name>",
 	Short: "Create a new D1 database",
-	Args:  cobra.ExactArgs(1),
+	Long:  "Create a new D1 database. A location is a hint for where the primary is placed; a jurisdiction guarantees the data stays within it and cannot be changed later.",
+	Example: `  cf d1 create my-db
+  cf d1 create my-db --location weur
+  cf d1 create my-db --jurisdiction eu`,
+	Args: cobra.ExactArgs(1),
 	Run: executor.New().
 		WithClient().
 		WithAccountID().
@@ -30,14 +42,36 @@ var createCmd = &cobra.Command{
 }
 
 func init() {
+	createCmd.Flags().String("location", "", "Location hint for the primary ("+strings.Join(databaseLocations, ", ")+")")
+	createCmd.Flags().String("jurisdiction", "", "Keep the data within a jurisdiction ("+strings.Join(databaseJurisdictions, ", ")+")")
+	createCmd.MarkFlagsMutuallyExclusive("location", "jurisdiction")
 	D1Cmd.AddCommand(createCmd)
 }
 
 func createDatabase(ctx *executor.Context, _ chan<- string) (*d1.D1, error) {
-	return ctx.Client.D1.Database.New(context.Background(), d1.DatabaseNewParams{
+	params := d1.DatabaseNewParams{
 		AccountID: cf.F(ctx.AccountID),
 		Name:      cf.F(ctx.Args[0]),
-	})
+	}
+	var opts []option.RequestOption
+
+	if location, _ := ctx.Cmd.Flags().GetString("location"); location != "" {
+		location = strings.ToLower(location)
+		if !slices.Contains(databaseLocations, location) {
+			return nil, fmt.Errorf("invalid --location %q, expected one of %s", location, strings.Join(databaseLocations, ", "))
+		}
+		params.PrimaryLocationHint = cf.F(d1.DatabaseNewParamsPrimaryLocationHint(location))
+	}
+	if jurisdiction, _ := ctx.Cmd.Flags().GetString("jurisdiction"); jurisdiction != "" {
+		jurisdiction = strings.ToLower(jurisdiction)
+		if !slices.Contains(databaseJurisdictions, jurisdiction) {
+			return nil, fmt.Errorf("invalid --jurisdiction %q, expected one of %s", jurisdiction, strings.Join(databaseJurisdictions, ", "))
+		}
+		// The SDK does not model the jurisdiction field yet.
+		opts = append(opts, option.WithJSONSet("jurisdiction", jurisdiction))
+	}
+
+	return ctx.Client.D1.Database.New(context.Background(), params, opts...)
 }
 
 func printCreateDatabase(ctx *executor.Context) {
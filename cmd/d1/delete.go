@@ -0,0 +1,65 @@
+package d1
+
+import (
+	"context"
+	"fmt"
+
+	"dario.lol/cf/internal/cloudflare"
+	"dario.lol/cf/internal/executor"
+	"dario.lol/cf/internal/flags"
+	"dario.lol/cf/internal/ui"
+	"dario.lol/cf/internal/ui/response"
+	cf "github.com/cloudflare/cloudflare-go/v6"
+	"github.com/cloudflare/cloudflare-go/v6/d1"
+	"github.com/spf13/cobra"
+)
+
+var deletedDatabaseKey = executor.NewKey[bool]("d1DeletedDatabase")
+
+var deleteCmd = &cobra.Command{
+	Use:   "delete <database>",
+	Short: "Delete a D1 database",
+	Long:  "Delete a D1 database and all of its data. Time Travel cannot restore a deleted database.",
+	Args:  cobra.ExactArgs(1),
+	Run: executor.New().
+		WithClient().
+		WithAccountID().
+		WithD1Database().
+		WithConfirmationFunc(func(ctx *executor.Context) string {
+			return fmt.Sprintf("Are you sure you want to delete database %s? This cannot be undone.", executor.Get(ctx, executor.D1DatabaseNameKey))
+		}).
+		Step(executor.NewStep(deletedDatabaseKey, "Deleting database").Func(deleteDatabase)).
+		Invalidates(func(ctx *executor.Context) []string {
+			return []string{"d1:databases:list"}
+		}).
+		Display(printDeleteDatabase).
+		Run(),
+}
+
+func init() {
+	flags.RegisterConfirmation(deleteCmd)
+	D1Cmd.AddCommand(deleteCmd)
+}
+
+func deleteDatabase(ctx *executor.Context, _ chan<- string) (bool, error) {
+	id := executor.Get(ctx, executor.D1DatabaseIDKey)
+	name := executor.Get(ctx, executor.D1DatabaseNameKey)
+	_, err := ctx.Client.D1.Database.Delete(context.Background(), id, d1.DatabaseDeleteParams{
+		AccountID: cf.F(ctx.AccountID),
+	})
+	if err != nil {
+		return false, err
+	}
+	cloudflare.DeleteID(cloudflare.D1DatabaseCacheKey(ctx.AccountID, name))
+	cloudflare.DeleteID(cloudflare.D1DatabaseCacheKey(ctx.AccountID, id))
+	return true, nil
+}
+
+func printDeleteDatabase(ctx *executor.Context) {
+	rb := response.New()
+	if ctx.Error != nil {
+		rb.Error("Error deleting database", ctx.Error).Display()
+		return
+	}
+	rb.FooterSuccessf("Successfully deleted database %s %s", executor.Get(ctx, executor.D1DatabaseNameKey), ui.Muted(fmt.Sprintf("(took %v)", ctx.Duration))).Display()
+}
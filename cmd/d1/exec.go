@@ -2,75 +2,165 @@ package d1
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
-	"sort"
+	"io"
+	"os"
+	"slices"
 	"strings"
 
 	"dario.lol/cf/internal/executor"
 	"dario.lol/cf/internal/ui"
 	"dario.lol/cf/internal/ui/response"
-	"github.com/charmbracelet/lipgloss"
-	"github.com/charmbracelet/lipgloss/table"
 	cf "github.com/cloudflare/cloudflare-go/v6"
 	"github.com/cloudflare/cloudflare-go/v6/d1"
 	"github.com/spf13/cobra"
 )
 
-var queryResultsKey = executor.NewKey[[]d1.QueryResult]("queryResults")
+// ExecResult is the outcome of a single statement. In batch mode every
+// statement shares the outcome of the batch.
+type ExecResult struct {
+	Statement Statement
+	Result    d1.QueryResult
+	Err       error
+}
+
+var execResultsKey = executor.NewKey[[]ExecResult]("d1ExecResults")
 
 var execCmd = &cobra.Command{
-	Use:   "exec <name> -- <query>",
+	Use:   "exec <name> [-- <query>]",
 	Short: "Execute a query against a D1 database",
-	Args:  cobra.MinimumNArgs(1),
+	Long: `Execute SQL against a D1 database, either from the arguments after -- or from a file with --file.
+
+Statements are split on semicolons, ignoring those inside strings, comments and trigger bodies, and run one request at a time. With --batch they are sent as a single batch, which D1 runs as a transaction: if any statement fails, none of them are applied.`,
+	Example: `  cf d1 exec my-db -- "SELECT * FROM users"
+  cf d1 exec my-db --param 42 -- "SELECT * FROM users WHERE id = ?1"
+  cf d1 exec my-db --file schema.sql --stop-on-error
+  cf d1 exec my-db --file seed.sql --batch
+  cf d1 exec my-db --format csv -- "SELECT * FROM users" > users.csv`,
+	Args: cobra.MinimumNArgs(1),
 	Run: executor.New().
 		WithClient().
 		WithAccountID().
-		Step(executor.NewStep(queryResultsKey, "Executing query").Func(execQueryFunc)).
+		WithD1Database().
+		Step(executor.NewStep(execResultsKey, "Executing query").Func(execQueryFunc)).
 		Display(printExecResult).
 		Run(),
 }
 
 func init() {
+	execCmd.Flags().StringP("file", "f", "", "Read SQL statements from a file (- for stdin)")
+	execCmd.Flags().StringArray("param", nil, "Positional parameter bound to ?1, ?2, ... (repeatable)")
+	execCmd.Flags().Bool("batch", false, "Send all statements in one atomic batch")
+	execCmd.Flags().Bool("stop-on-error", false, "Stop at the first failing statement")
+	execCmd.Flags().String("format", "table", "Output format: table, csv, tsv, json or markdown")
 	D1Cmd.AddCommand(execCmd)
 }
 
-func execQueryFunc(ctx *executor.Context, _ chan<- string) ([]d1.QueryResult, error) {
-	dbName := ctx.Args[0]
+// readStatements returns the statements to run and a label for their source.
+func readStatements(ctx *executor.Context) ([]Statement, string, error) {
+	path, _ := ctx.Cmd.Flags().GetString("file")
+	if path == "" {
+		if len(ctx.Args) < 2 {
+			return nil, "", fmt.Errorf("please provide a query after the database name, e.g. `cf d1 exec my-db -- 'SELECT * FROM users'`, or use --file")
+		}
+		return splitStatements(strings.Join(ctx.Args[1:], " ")), "query", nil
+	}
+	if len(ctx.Args) > 1 {
+		return nil, "", fmt.Errorf("--file cannot be combined with a query argument")
+	}
 
-	if len(ctx.Args) < 2 {
-		return nil, fmt.Errorf("please provide a query after the database name, e.g. `cf d1 exec my-db -- 'SELECT * FROM users'`")
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+		path = "stdin"
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("error reading SQL file: %w", err)
 	}
+	return splitStatements(string(data)), path, nil
+}
 
-	query := strings.Join(ctx.Args[1:], " ")
+func execQueryFunc(ctx *executor.Context, progress chan<- string) ([]ExecResult, error) {
+	format, _ := ctx.Cmd.Flags().GetString("format")
+	if !slices.Contains(outputFormats, format) {
+		return nil, fmt.Errorf("invalid --format %q, expected one of %s", format, strings.Join(outputFormats, ", "))
+	}
 
-	pager := ctx.Client.D1.Database.ListAutoPaging(context.Background(), d1.DatabaseListParams{
-		AccountID: cf.F(ctx.AccountID),
-	})
-
-	var targetUUID string
-	for pager.Next() {
-		db := pager.Current()
-		if db.Name == dbName || db.UUID == dbName {
-			targetUUID = db.UUID
-			break
-		}
+	statements, source, err := readStatements(ctx)
+	if err != nil {
+		return nil, err
 	}
-	if err := pager.Err(); err != nil {
-		return nil, fmt.Errorf("error listing databases: %w", err)
+	if len(statements) == 0 {
+		return nil, fmt.Errorf("no SQL statements found in %s", source)
 	}
 
-	if targetUUID == "" {
-		return nil, fmt.Errorf("database '%s' not found", dbName)
+	params, _ := ctx.Cmd.Flags().GetStringArray("param")
+	if len(params) > 0 && len(statements) > 1 {
+		return nil, fmt.Errorf("--param can only be used with a single statement, got %d", len(statements))
 	}
 
-	page, err := ctx.Client.D1.Database.Query(context.Background(), targetUUID, d1.DatabaseQueryParams{
+	batch, _ := ctx.Cmd.Flags().GetBool("batch")
+	stopOnError, _ := ctx.Cmd.Flags().GetBool("stop-on-error")
+	dbID := executor.Get(ctx, executor.D1DatabaseIDKey)
+
+	if batch {
+		sqls := make([]string, len(statements))
+		for i, stmt := range statements {
+			sqls[i] = stmt.SQL
+		}
+		if progress != nil {
+			progress <- fmt.Sprintf("Executing batch of %d statement(s)", len(statements))
+		}
+		queryResults, err := runQuery(ctx, dbID, strings.Join(sqls, ";\n"), params)
+		if err != nil {
+			return nil, fmt.Errorf("batch failed and was rolled back, no statements were applied: %w", err)
+		}
+		results := make([]ExecResult, len(statements))
+		for i, stmt := range statements {
+			results[i].Statement = stmt
+			if i < len(queryResults) {
+				results[i].Result = queryResults[i]
+			}
+		}
+		return results, nil
+	}
+
+	results := make([]ExecResult, 0, len(statements))
+	for i, stmt := range statements {
+		if progress != nil && len(statements) > 1 {
+			progress <- fmt.Sprintf("Executing statement %d/%d", i+1, len(statements))
+		}
+		result := ExecResult{Statement: stmt}
+		queryResults, err := runQuery(ctx, dbID, stmt.SQL, params)
+		if err != nil {
+			if stopOnError {
+				return nil, fmt.Errorf("statement %d at %s:%d failed (%d earlier statement(s) applied): %w\n\n%s", i+1, source, stmt.Line, i, err, stmt.SQL)
+			}
+			result.Err = err
+		} else if len(queryResults) > 0 {
+			result.Result = queryResults[0]
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+func runQuery(ctx *executor.Context, dbID, sql string, params []string) ([]d1.QueryResult, error) {
+	query := d1.DatabaseQueryParams{
 		AccountID: cf.F(ctx.AccountID),
-		Sql:       cf.F(query),
-	})
+		Sql:       cf.F(sql),
+	}
+	if len(params) > 0 {
+		query.Params = cf.F(params)
+	}
+	page, err := ctx.Client.D1.Database.Query(context.Background(), dbID, query)
 	if err != nil {
 		return nil, err
 	}
-
 	return page.Result, nil
 }
 
@@ -81,63 +171,141 @@ func printExecResult(ctx *executor.Context) {
 		return
 	}
 
-	results := executor.Get(ctx, queryResultsKey)
+	results := executor.Get(ctx, execResultsKey)
+	format, _ := ctx.Cmd.Flags().GetString("format")
+	if format != "table" {
+		printFormattedResults(ctx, results, format)
+		return
+	}
 
+	failed := 0
 	for i, res := range results {
-		if !res.Success {
-			rb.AddItem(fmt.Sprintf("Result %d (Failed)", i+1), ui.Error("Query failed"))
+		title := fmt.Sprintf("Result %d", i+1)
+		if len(results) > 1 {
+			title = fmt.Sprintf("Statement %d (line %d)", i+1, res.Statement.Line)
+		}
+
+		if res.Err != nil {
+			failed++
+			rb.AddItem(title+" (Failed)", ui.Error(res.Err.Error())+"\n\n"+ui.Muted(res.Statement.SQL))
+			continue
+		}
+		if !res.Result.Success {
+			failed++
+			rb.AddItem(title+" (Failed)", ui.Error("Query failed"))
 			continue
 		}
 
-		if len(res.Results) > 0 {
-			firstRow, ok := res.Results[0].(map[string]interface{})
-			if !ok {
-				rb.AddItem(fmt.Sprintf("Result %d", i+1), fmt.Sprintf("Rows: %d", len(res.Results)))
-				rb.AddItem("Sample", fmt.Sprintf("%v", res.Results[0]))
+		rb.AddItem(title, renderQueryTable(res.Result, 40)+"\n\n"+ui.Muted(metaSummary(res.Result.Meta)))
+	}
+
+	rb.FooterSuccess(execFooter(ctx, results, failed)).Display()
+}
+
+// printFormattedResults writes rows to stdout in a machine-readable format
+// and keeps errors and the summary on stderr, so output can be piped.
+func printFormattedResults(ctx *executor.Context, results []ExecResult, format string) {
+	failed := 0
+	for _, res := range results {
+		if res.Err != nil || !res.Result.Success {
+			failed++
+		}
+	}
+
+	var err error
+	if format == "json" {
+		err = writeResultsJSON(os.Stdout, results)
+	} else {
+		first := true
+		for i, res := range results {
+			if res.Err != nil {
+				fmt.Fprintln(os.Stderr, ui.Error(fmt.Sprintf("Statement %d (line %d) failed: %v", i+1, res.Statement.Line, res.Err)))
 				continue
 			}
-
-			headers := make([]string, 0, len(firstRow))
-			for k := range firstRow {
-				headers = append(headers, k)
+			set := parseResultSet(res.Result)
+			if len(set.Columns) == 0 {
+				continue
+			}
+			if !first {
+				fmt.Fprintln(os.Stdout)
 			}
-			sort.Strings(headers)
-
-			t := table.New().
-				Border(lipgloss.HiddenBorder()).
-				BorderStyle(lipgloss.NewStyle().Foreground(ui.C.Gray500)).
-				Headers(headers...)
-
-			for _, rowInterface := range res.Results {
-				rowMap, ok := rowInterface.(map[string]interface{})
-				if !ok {
-					continue
-				}
-				row := make([]string, 0, len(headers))
-				for _, h := range headers {
-					val := rowMap[h]
-					if val == nil {
-						row = append(row, "NULL")
-					} else {
-						strVal := fmt.Sprintf("%v", val)
-						strVal = strings.ReplaceAll(strVal, "\n", " ")
-						strVal = strings.ReplaceAll(strVal, "\r", " ")
-						strVal = strings.ReplaceAll(strVal, "\t", " ")
-
-						if len(strVal) > 40 {
-							strVal = strVal[:37] + "..."
-						}
-						row = append(row, strVal)
-					}
-				}
-				t.Row(row...)
+			first = false
+			switch format {
+			case "csv":
+				err = writeDelimited(os.Stdout, set, ',')
+			case "tsv":
+				err = writeDelimited(os.Stdout, set, '\t')
+			case "markdown":
+				err = writeMarkdown(os.Stdout, set)
 			}
+			if err != nil {
+				break
+			}
+		}
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, ui.ErrorMessage("Error writing results", err))
+		return
+	}
+
+	fmt.Fprintln(os.Stderr, ui.Success(execFooter(ctx, results, failed)))
+}
+
+func writeResultsJSON(w io.Writer, results []ExecResult) error {
+	type statementOutput struct {
+		Statement string          `json:"statement"`
+		Line      int             `json:"line"`
+		Success   bool            `json:"success"`
+		Error     string          `json:"error,omitempty"`
+		Columns   []string        `json:"columns"`
+		Results   []orderedRow    `json:"results"`
+		Meta      json.RawMessage `json:"meta,omitempty"`
+	}
 
-			rb.AddItem(fmt.Sprintf("Result %d", i+1), t.Render())
+	out := make([]statementOutput, 0, len(results))
+	for _, res := range results {
+		o := statementOutput{
+			Statement: res.Statement.SQL,
+			Line:      res.Statement.Line,
+			Success:   res.Err == nil && res.Result.Success,
+			Columns:   []string{},
+			Results:   []orderedRow{},
+		}
+		if res.Err != nil {
+			o.Error = res.Err.Error()
 		} else {
-			rb.AddItem(fmt.Sprintf("Result %d", i+1), "No rows returned")
+			set := parseResultSet(res.Result)
+			if set.Columns != nil {
+				o.Columns = set.Columns
+			}
+			for _, values := range set.Rows {
+				o.Results = append(o.Results, orderedRow{columns: set.Columns, values: values})
+			}
+			o.Meta = json.RawMessage(res.Result.JSON.Meta.Raw())
 		}
+		out = append(out, o)
 	}
 
-	rb.FooterSuccessf("Executed successfully %s", ui.Muted(fmt.Sprintf("(took %v)", ctx.Duration))).Display()
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
+
+func execFooter(ctx *executor.Context, results []ExecResult, failed int) string {
+	var footer string
+	if len(results) == 1 && failed == 0 {
+		footer = "Executed successfully"
+	} else {
+		footer = fmt.Sprintf("Executed %d statement(s)", len(results))
+	}
+	if failed > 0 {
+		footer += " " + ui.Warning(fmt.Sprintf("(%d failed)", failed))
+	}
+	var read, written float64
+	for _, res := range results {
+		read += res.Result.Meta.RowsRead
+		written += res.Result.Meta.RowsWritten
+	}
+	footer += " " + ui.Muted(fmt.Sprintf("(%.0f row(s) read, %.0f written, took %v)", read, written, ctx.Duration))
+	return footer
 }
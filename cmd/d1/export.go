@@ -0,0 +1,220 @@
+package d1
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"dario.lol/cf/internal/executor"
+	"dario.lol/cf/internal/ui"
+	"dario.lol/cf/internal/ui/response"
+	cf "github.com/cloudflare/cloudflare-go/v6"
+	"github.com/cloudflare/cloudflare-go/v6/d1"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// pollInterval is how long export and import wait between status checks.
+	pollInterval = time.Second
+	// defaultPollTimeout is how long export and import wait for the remote
+	// job before giving up.
+	defaultPollTimeout = 30 * time.Minute
+)
+
+// pollDeadline returns when polling started now should give up, per --timeout.
+func pollDeadline(ctx *executor.Context) (time.Time, time.Duration, error) {
+	timeout, _ := ctx.Cmd.Flags().GetDuration("timeout")
+	if timeout <= 0 {
+		return time.Time{}, 0, fmt.Errorf("--timeout must be positive")
+	}
+	return time.Now().Add(timeout), timeout, nil
+}
+
+type ExportResult struct {
+	Path       string `json:"path"`
+	Size       int64  `json:"size"`
+	Bookmark   string `json:"bookmark"`
+	SQLitePath string `json:"sqlite_path,omitempty"`
+}
+
+var exportResultKey = executor.NewKey[*ExportResult]("d1ExportResult")
+
+var exportCmd = &cobra.Command{
+	Use:   "export <database>",
+	Short: "Export a D1 database as a SQL dump",
+	Long: `Export a D1 database's schema and data as a SQL dump. The export runs remotely and is polled until the dump is ready to download.
+
+With --sqlite the dump is also loaded into a local SQLite database file using the sqlite3 command.`,
+	Example: `  cf d1 export my-db --output dump.sql
+  cf d1 export my-db --no-data --output schema.sql
+  cf d1 export my-db --table users --table posts --sqlite local.db`,
+	Args: cobra.ExactArgs(1),
+	Run: executor.New().
+		WithClient().
+		WithAccountID().
+		WithD1Database().
+		Step(executor.NewStep(exportResultKey, "Exporting database").Func(exportDatabase)).
+		Display(printExportDatabase).
+		Run(),
+}
+
+func init() {
+	exportCmd.Flags().StringP("output", "o", "", "File to write the dump to (default: <database>.sql)")
+	exportCmd.Flags().Bool("no-data", false, "Export only the schema")
+	exportCmd.Flags().Bool("no-schema", false, "Export only the data")
+	exportCmd.Flags().StringSlice("table", nil, "Export only this table (repeatable)")
+	exportCmd.Flags().String("sqlite", "", "Also load the dump into this local SQLite database file")
+	exportCmd.Flags().Duration("timeout", defaultPollTimeout, "How long to wait for the export to finish")
+	D1Cmd.AddCommand(exportCmd)
+}
+
+func exportDatabase(ctx *executor.Context, progress chan<- string) (*ExportResult, error) {
+	output, _ := ctx.Cmd.Flags().GetString("output")
+	noData, _ := ctx.Cmd.Flags().GetBool("no-data")
+	noSchema, _ := ctx.Cmd.Flags().GetBool("no-schema")
+	tables, _ := ctx.Cmd.Flags().GetStringSlice("table")
+	sqlitePath, _ := ctx.Cmd.Flags().GetString("sqlite")
+
+	if noData && noSchema {
+		return nil, fmt.Errorf("--no-data and --no-schema cannot be used together")
+	}
+	if sqlitePath != "" {
+		if _, err := exec.LookPath("sqlite3"); err != nil {
+			return nil, fmt.Errorf("--sqlite requires the sqlite3 command: %w", err)
+		}
+	}
+	if output == "" {
+		output = executor.Get(ctx, executor.D1DatabaseNameKey) + ".sql"
+	}
+
+	params := d1.DatabaseExportParams{
+		AccountID:    cf.F(ctx.AccountID),
+		OutputFormat: cf.F(d1.DatabaseExportParamsOutputFormatPolling),
+	}
+	dumpOptions := d1.DatabaseExportParamsDumpOptions{
+		NoData:   cf.F(noData),
+		NoSchema: cf.F(noSchema),
+	}
+	if len(tables) > 0 {
+		dumpOptions.Tables = cf.F(tables)
+	}
+	params.DumpOptions = cf.F(dumpOptions)
+	dbID := executor.Get(ctx, executor.D1DatabaseIDKey)
+	deadline, timeout, err := pollDeadline(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var res *d1.DatabaseExportResponse
+	for {
+		var err error
+		res, err = ctx.Client.D1.Database.Export(context.Background(), dbID, params)
+		if err != nil {
+			return nil, err
+		}
+		if len(res.Messages) > 0 {
+			progress <- fmt.Sprintf("Exporting database: %s", res.Messages[len(res.Messages)-1])
+		}
+		if res.Status == d1.DatabaseExportResponseStatusError {
+			return nil, fmt.Errorf("export failed: %s", res.Error)
+		}
+		if res.Status == d1.DatabaseExportResponseStatusComplete && res.Result.SignedURL != "" {
+			break
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("export did not finish within %s, last bookmark: %s", timeout, res.AtBookmark)
+		}
+		params.CurrentBookmark = cf.F(res.AtBookmark)
+		time.Sleep(pollInterval)
+	}
+
+	progress <- "Downloading dump"
+	size, err := downloadFile(res.Result.SignedURL, output)
+	if err != nil {
+		return nil, fmt.Errorf("error downloading dump: %w", err)
+	}
+
+	result := &ExportResult{Path: output, Size: size, Bookmark: res.AtBookmark}
+	if sqlitePath != "" {
+		progress <- fmt.Sprintf("Loading dump into %s", sqlitePath)
+		if err := loadSQLite(output, sqlitePath); err != nil {
+			return nil, err
+		}
+		result.SQLitePath = sqlitePath
+	}
+	return result, nil
+}
+
+// downloadFile writes url to path through a temporary file, so an
+// interrupted download never leaves a truncated dump behind.
+func downloadFile(url, path string) (int64, error) {
+	resp, err := http.Get(url)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".cf-export-*")
+	if err != nil {
+		return 0, err
+	}
+	size, err := io.Copy(tmp, resp.Body)
+	if err == nil {
+		err = tmp.Chmod(0o644)
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return 0, err
+	}
+	return size, nil
+}
+
+func loadSQLite(dumpPath, dbPath string) error {
+	dump, err := os.Open(dumpPath)
+	if err != nil {
+		return err
+	}
+	defer dump.Close()
+
+	cmd := exec.Command("sqlite3", "-bail", dbPath)
+	cmd.Stdin = dump
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("error loading dump into %s: %w: %s", dbPath, err, out)
+	}
+	return nil
+}
+
+func printExportDatabase(ctx *executor.Context) {
+	rb := response.New()
+	if ctx.Error != nil {
+		rb.Error("Error exporting database", ctx.Error).Display()
+		return
+	}
+
+	result := executor.Get(ctx, exportResultKey)
+	icb := response.NewItemContent().
+		Add("File:", ui.Text(result.Path)).
+		Add("Size:", ui.Text(ui.FormatBytes(result.Size))).
+		Add("Bookmark:", ui.Text(result.Bookmark))
+	if result.SQLitePath != "" {
+		icb.Add("SQLite:", ui.Text(result.SQLitePath))
+	}
+
+	rb.AddItem(executor.Get(ctx, executor.D1DatabaseNameKey), icb.String()).
+		FooterSuccessf("Exported %s to %s %s", executor.Get(ctx, executor.D1DatabaseNameKey), result.Path, ui.Muted(fmt.Sprintf("(took %v)", ctx.Duration))).
+		Display()
+}
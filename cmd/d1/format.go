@@ -0,0 +1,252 @@
+package d1
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"dario.lol/cf/internal/ui"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/lipgloss/table"
+	"github.com/cloudflare/cloudflare-go/v6/d1"
+)
+
+var outputFormats = []string{"table", "csv", "tsv", "json", "markdown"}
+
+// ResultSet holds query rows with columns in the order the query returned
+// them.
+type ResultSet struct {
+	Columns []string
+	Rows    [][]any
+}
+
+// parseResultSet decodes the raw result rows so that column order and
+// integer precision survive; the SDK decodes rows into maps of float64.
+func parseResultSet(res d1.QueryResult) ResultSet {
+	var set ResultSet
+	var rawRows []json.RawMessage
+	if err := json.Unmarshal([]byte(res.JSON.Results.Raw()), &rawRows); err != nil {
+		return resultSetFromMaps(res.Results)
+	}
+
+	index := make(map[string]int)
+	for _, raw := range rawRows {
+		keys, values, err := decodeOrderedObject(raw)
+		if err != nil {
+			continue
+		}
+		for _, key := range keys {
+			if _, ok := index[key]; !ok {
+				index[key] = len(set.Columns)
+				set.Columns = append(set.Columns, key)
+			}
+		}
+		row := make([]any, len(set.Columns))
+		for i, key := range keys {
+			row[index[key]] = values[i]
+		}
+		set.Rows = append(set.Rows, row)
+	}
+	for i, row := range set.Rows {
+		if len(row) < len(set.Columns) {
+			set.Rows[i] = append(row, make([]any, len(set.Columns)-len(row))...)
+		}
+	}
+	return set
+}
+
+func resultSetFromMaps(rows []interface{}) ResultSet {
+	var set ResultSet
+	if len(rows) == 0 {
+		return set
+	}
+	first, ok := rows[0].(map[string]interface{})
+	if !ok {
+		return set
+	}
+	for k := range first {
+		set.Columns = append(set.Columns, k)
+	}
+	sort.Strings(set.Columns)
+	for _, r := range rows {
+		fields, ok := r.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		row := make([]any, len(set.Columns))
+		for i, col := range set.Columns {
+			row[i] = fields[col]
+		}
+		set.Rows = append(set.Rows, row)
+	}
+	return set
+}
+
+func decodeOrderedObject(data []byte) ([]string, []any, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return nil, nil, fmt.Errorf("expected a JSON object")
+	}
+	var keys []string
+	var values []any
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, nil, err
+		}
+		var value any
+		if err := dec.Decode(&value); err != nil {
+			return nil, nil, err
+		}
+		keys = append(keys, tok.(string))
+		values = append(values, value)
+	}
+	return keys, values, nil
+}
+
+func formatValue(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case []any, map[string]any:
+		data, _ := json.Marshal(v)
+		return string(data)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// renderQueryTable renders result rows as a table, shortening values to
+// maxWidth characters unless maxWidth is 0.
+func renderQueryTable(res d1.QueryResult, maxWidth int) string {
+	set := parseResultSet(res)
+	if len(set.Rows) == 0 {
+		return "No rows returned"
+	}
+
+	t := table.New().
+		Border(lipgloss.HiddenBorder()).
+		BorderStyle(lipgloss.NewStyle().Foreground(ui.C.Gray500)).
+		Headers(set.Columns...)
+
+	for _, values := range set.Rows {
+		row := make([]string, len(values))
+		for i, val := range values {
+			if val == nil {
+				row[i] = "NULL"
+				continue
+			}
+			strVal := formatValue(val)
+			strVal = strings.ReplaceAll(strVal, "\n", " ")
+			strVal = strings.ReplaceAll(strVal, "\r", " ")
+			strVal = strings.ReplaceAll(strVal, "\t", " ")
+
+			if maxWidth > 0 && len(strVal) > maxWidth {
+				strVal = strVal[:maxWidth-3] + "..."
+			}
+			row[i] = strVal
+		}
+		t.Row(row...)
+	}
+
+	return t.Render()
+}
+
+func writeDelimited(w io.Writer, set ResultSet, comma rune) error {
+	cw := csv.NewWriter(w)
+	cw.Comma = comma
+	if err := cw.Write(set.Columns); err != nil {
+		return err
+	}
+	for _, values := range set.Rows {
+		record := make([]string, len(values))
+		for i, val := range values {
+			record[i] = formatValue(val)
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func writeMarkdown(w io.Writer, set ResultSet) error {
+	escape := strings.NewReplacer("|", `\|`, "\r\n", "<br>", "\n", "<br>")
+	var sb strings.Builder
+	sb.WriteString("|")
+	for _, col := range set.Columns {
+		sb.WriteString(" " + escape.Replace(col) + " |")
+	}
+	sb.WriteString("\n|")
+	for range set.Columns {
+		sb.WriteString(" --- |")
+	}
+	sb.WriteString("\n")
+	for _, values := range set.Rows {
+		sb.WriteString("|")
+		for _, val := range values {
+			s := "NULL"
+			if val != nil {
+				s = escape.Replace(formatValue(val))
+			}
+			sb.WriteString(" " + s + " |")
+		}
+		sb.WriteString("\n")
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// orderedRow marshals a row as a JSON object with keys in column order.
+type orderedRow struct {
+	columns []string
+	values  []any
+}
+
+func (r orderedRow) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, col := range r.columns {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(col)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(r.values[i])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// metaSummary describes a query's cost and where it ran.
+func metaSummary(meta d1.QueryResultMeta) string {
+	parts := []string{
+		fmt.Sprintf("%.0f row(s) read", meta.RowsRead),
+		fmt.Sprintf("%.0f written", meta.RowsWritten),
+		fmt.Sprintf("%.2fms in database", meta.Duration),
+	}
+	if meta.ServedByRegion != "" {
+		served := "served by " + string(meta.ServedByRegion)
+		if meta.ServedByPrimary {
+			served += " (primary)"
+		}
+		parts = append(parts, served)
+	}
+	return strings.Join(parts, ", ")
+}
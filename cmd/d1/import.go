@@ -0,0 +1,160 @@
+package d1
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"dario.lol/cf/internal/executor"
+	"dario.lol/cf/internal/flags"
+	"dario.lol/cf/internal/ui"
+	"dario.lol/cf/internal/ui/response"
+	cf "github.com/cloudflare/cloudflare-go/v6"
+	"github.com/cloudflare/cloudflare-go/v6/d1"
+	"github.com/spf13/cobra"
+)
+
+var importResultKey = executor.NewKey[*d1.DatabaseImportResponse]("d1ImportResult")
+
+var importCmd = &cobra.Command{
+	Use:   "import <database> <file.sql>",
+	Short: "Import a SQL dump into a D1 database",
+	Long:  "Upload a SQL dump and run it against a D1 database. The database is unavailable for other queries while the import runs.",
+	Example: `  cf d1 import staging-db dump.sql
+  cf d1 import staging-db seed.sql --yes`,
+	Args: cobra.ExactArgs(2),
+	Run: executor.New().
+		WithClient().
+		WithAccountID().
+		WithD1Database().
+		WithConfirmationFunc(func(ctx *executor.Context) string {
+			return fmt.Sprintf("Import %s into %s? The database will be unavailable while the import runs.", ctx.Args[1], executor.Get(ctx, executor.D1DatabaseNameKey))
+		}).
+		Step(executor.NewStep(importResultKey, "Importing dump").Func(importDatabase)).
+		Display(printImportDatabase).
+		Run(),
+}
+
+func init() {
+	importCmd.Flags().Duration("timeout", defaultPollTimeout, "How long to wait for the import to finish")
+	flags.RegisterConfirmation(importCmd)
+	D1Cmd.AddCommand(importCmd)
+}
+
+func importDatabase(ctx *executor.Context, progress chan<- string) (*d1.DatabaseImportResponse, error) {
+	deadline, timeout, err := pollDeadline(ctx)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(ctx.Args[1])
+	if err != nil {
+		return nil, fmt.Errorf("error reading dump: %w", err)
+	}
+	sum := md5.Sum(data)
+	etag := hex.EncodeToString(sum[:])
+	dbID := executor.Get(ctx, executor.D1DatabaseIDKey)
+
+	res, err := ctx.Client.D1.Database.Import(context.Background(), dbID, d1.DatabaseImportParams{
+		AccountID: cf.F(ctx.AccountID),
+		Body: d1.DatabaseImportParamsBodyInit{
+			Action: cf.F(d1.DatabaseImportParamsBodyInitActionInit),
+			Etag:   cf.F(etag),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Without an upload URL the same file has already been uploaded.
+	if res.UploadURL != "" {
+		progress <- fmt.Sprintf("Uploading %s (%s)", ctx.Args[1], ui.FormatBytes(int64(len(data))))
+		if err := uploadDump(res.UploadURL, data, etag); err != nil {
+			return nil, fmt.Errorf("error uploading dump: %w", err)
+		}
+	}
+
+	progress <- "Starting import"
+	res, err = ctx.Client.D1.Database.Import(context.Background(), dbID, d1.DatabaseImportParams{
+		AccountID: cf.F(ctx.AccountID),
+		Body: d1.DatabaseImportParamsBodyIngest{
+			Action:   cf.F(d1.DatabaseImportParamsBodyIngestActionIngest),
+			Etag:     cf.F(etag),
+			Filename: cf.F(res.Filename),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		if len(res.Messages) > 0 {
+			progress <- fmt.Sprintf("Importing dump: %s", res.Messages[len(res.Messages)-1])
+		}
+		switch res.Status {
+		case d1.DatabaseImportResponseStatusComplete:
+			return res, nil
+		case d1.DatabaseImportResponseStatusError:
+			return nil, fmt.Errorf("import failed: %s", res.Error)
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("import did not finish within %s, last bookmark: %s", timeout, res.AtBookmark)
+		}
+
+		time.Sleep(pollInterval)
+		res, err = ctx.Client.D1.Database.Import(context.Background(), dbID, d1.DatabaseImportParams{
+			AccountID: cf.F(ctx.AccountID),
+			Body: d1.DatabaseImportParamsBodyPoll{
+				Action:          cf.F(d1.DatabaseImportParamsBodyPollActionPoll),
+				CurrentBookmark: cf.F(res.AtBookmark),
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+func uploadDump(url string, data []byte, etag string) error {
+	req, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	if got := strings.Trim(resp.Header.Get("ETag"), `"`); got != "" && got != etag {
+		return fmt.Errorf("upload checksum mismatch, expected %s, got %s", etag, got)
+	}
+	return nil
+}
+
+func printImportDatabase(ctx *executor.Context) {
+	rb := response.New()
+	if ctx.Error != nil {
+		rb.Error("Error importing dump", ctx.Error).Display()
+		return
+	}
+
+	res := executor.Get(ctx, importResultKey)
+	name := executor.Get(ctx, executor.D1DatabaseNameKey)
+	icb := response.NewItemContent().
+		Add("File:", ui.Text(ctx.Args[1])).
+		Add("Queries:", ui.Text(fmt.Sprintf("%.0f", res.Result.NumQueries))).
+		Add("Rows Written:", ui.Text(fmt.Sprintf("%.0f", res.Result.Meta.RowsWritten))).
+		Add("Bookmark:", ui.Text(res.Result.FinalBookmark))
+
+	rb.AddItem(name, icb.String()).
+		FooterSuccessf("Imported %s into %s %s", ctx.Args[1], name, ui.Muted(fmt.Sprintf("(took %v)", ctx.Duration))).
+		Display()
+}
@@ -0,0 +1,109 @@
+package d1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"dario.lol/cf/internal/executor"
+	"dario.lol/cf/internal/ui"
+	"dario.lol/cf/internal/ui/response"
+	cf "github.com/cloudflare/cloudflare-go/v6"
+	"github.com/cloudflare/cloudflare-go/v6/d1"
+	"github.com/spf13/cobra"
+)
+
+type DatabaseInfo struct {
+	Database *d1.D1 `json:"database"`
+	Region   string `json:"region"`
+}
+
+var databaseInfoKey = executor.NewKey[*DatabaseInfo]("d1DatabaseInfo")
+
+var infoCmd = &cobra.Command{
+	Use:   "info <database>",
+	Short: "Show details of a D1 database",
+	Args:  cobra.ExactArgs(1),
+	Run: executor.New().
+		WithClient().
+		WithAccountID().
+		WithD1Database().
+		Step(executor.NewStep(databaseInfoKey, "Fetching database").Func(getDatabaseInfo)).
+		Display(printDatabaseInfo).
+		Run(),
+}
+
+func init() {
+	infoCmd.Flags().Bool("probe-region", false, "Run a trivial query to find the primary's region when the API omits it (billed as a read)")
+	D1Cmd.AddCommand(infoCmd)
+}
+
+// getDatabaseInfo fetches the database and the region of its primary
+// instance. When the API omits the region it stays unknown unless
+// --probe-region asks for a trivial query to find it.
+func getDatabaseInfo(ctx *executor.Context, _ chan<- string) (*DatabaseInfo, error) {
+	dbID := executor.Get(ctx, executor.D1DatabaseIDKey)
+	database, err := ctx.Client.D1.Database.Get(context.Background(), dbID, d1.DatabaseGetParams{
+		AccountID: cf.F(ctx.AccountID),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	info := &DatabaseInfo{Database: database, Region: databaseExtra(database, "running_in_region")}
+	if probe, _ := ctx.Cmd.Flags().GetBool("probe-region"); probe && info.Region == "" {
+		if results, err := runQuery(ctx, dbID, "SELECT 1", nil); err == nil && len(results) > 0 && results[0].Meta.ServedByPrimary {
+			info.Region = string(results[0].Meta.ServedByRegion)
+		}
+	}
+	return info, nil
+}
+
+// databaseExtra returns a string field the API sends that the SDK does not
+// model yet.
+func databaseExtra(db *d1.D1, key string) string {
+	field, ok := db.JSON.ExtraFields[key]
+	if !ok {
+		return ""
+	}
+	var value string
+	_ = json.Unmarshal([]byte(field.Raw()), &value)
+	return value
+}
+
+func printDatabaseInfo(ctx *executor.Context) {
+	rb := response.New()
+	if ctx.Error != nil {
+		rb.Error("Error fetching database", ctx.Error).Display()
+		return
+	}
+
+	info := executor.Get(ctx, databaseInfoKey)
+	db := info.Database
+	region := info.Region
+	if region == "" {
+		region = "unknown"
+	}
+	replication := string(db.ReadReplication.Mode)
+	if replication == "" {
+		replication = "disabled"
+	}
+
+	icb := response.NewItemContent().
+		Add("ID:", ui.Text(db.UUID)).
+		Add("Size:", ui.Text(ui.FormatBytes(int64(db.FileSize)))).
+		Add("Tables:", ui.Text(fmt.Sprintf("%.0f", db.NumTables))).
+		Add("Version:", ui.Text(db.Version)).
+		Add("Region:", ui.Text(region)).
+		Add("Read Replication:", ui.Text(replication))
+	if jurisdiction := databaseExtra(db, "jurisdiction"); jurisdiction != "" {
+		icb.Add("Jurisdiction:", ui.Text(jurisdiction))
+	}
+	if !db.CreatedAt.IsZero() {
+		icb.Add("Created:", ui.Text(db.CreatedAt.Local().Format("2006-01-02 15:04:05")))
+	}
+
+	rb.AddItem(db.Name, icb.String()).
+		FooterSuccessf("Fetched database %s %s", db.Name, ui.Muted(fmt.Sprintf("(took %v)", ctx.Duration))).
+		Display()
+}
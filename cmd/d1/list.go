@@ -3,17 +3,31 @@ package d1
 import (
 	"context"
 	"fmt"
+	"sync/atomic"
+	"time"
 
 	"dario.lol/cf/internal/executor"
 	"dario.lol/cf/internal/pagination"
 	"dario.lol/cf/internal/ui"
 	"dario.lol/cf/internal/ui/response"
+	"github.com/alitto/pond/v2"
 	cf "github.com/cloudflare/cloudflare-go/v6"
 	"github.com/cloudflare/cloudflare-go/v6/d1"
 	"github.com/spf13/cobra"
 )
 
-var databasesKey = executor.NewKey[[]d1.DatabaseListResponse]("databases")
+type Database struct {
+	UUID            string    `json:"uuid"`
+	Name            string    `json:"name"`
+	CreatedAt       time.Time `json:"created_at"`
+	Region          string    `json:"region"`
+	Jurisdiction    string    `json:"jurisdiction"`
+	ReadReplication string    `json:"read_replication"`
+	// DetailsUnknown is set when fetching the database's details failed.
+	DetailsUnknown bool `json:"details_unknown,omitempty"`
+}
+
+var databasesKey = executor.NewKey[[]Database]("databases")
 
 var listCmd = &cobra.Command{
 	Use:   "list",
@@ -34,7 +48,10 @@ func init() {
 	D1Cmd.AddCommand(listCmd)
 }
 
-func listDatabases(ctx *executor.Context, _ chan<- string) ([]d1.DatabaseListResponse, error) {
+// listDatabases lists databases and fetches each one's details, since the
+// list endpoint omits location and replication settings. A database whose
+// details can't be fetched is still listed, with those settings unknown.
+func listDatabases(ctx *executor.Context, progress chan<- string) ([]Database, error) {
 	pager := ctx.Client.D1.Database.ListAutoPaging(context.Background(), d1.DatabaseListParams{
 		AccountID: cf.F(ctx.AccountID),
 	})
@@ -46,7 +63,30 @@ func listDatabases(ctx *executor.Context, _ chan<- string) ([]d1.DatabaseListRes
 	if err := pager.Err(); err != nil {
 		return nil, err
 	}
-	return all, nil
+
+	pool := pond.NewResultPool[Database](10)
+	group := pool.NewGroup()
+
+	var completed atomic.Int32
+	for _, item := range all {
+		group.Submit(func() Database {
+			db := Database{UUID: item.UUID, Name: item.Name, CreatedAt: item.CreatedAt}
+			details, err := ctx.Client.D1.Database.Get(context.Background(), item.UUID, d1.DatabaseGetParams{
+				AccountID: cf.F(ctx.AccountID),
+			})
+			if err != nil {
+				db.DetailsUnknown = true
+			} else {
+				db.Region = databaseExtra(details, "running_in_region")
+				db.Jurisdiction = databaseExtra(details, "jurisdiction")
+				db.ReadReplication = string(details.ReadReplication.Mode)
+			}
+			executor.TryProgress(progress, fmt.Sprintf("Fetching database details (%d/%d)", completed.Add(1), len(all)))
+			return db
+		})
+	}
+
+	return group.Wait()
 }
 
 func printListDatabases(ctx *executor.Context) {
@@ -61,7 +101,23 @@ func printListDatabases(ctx *executor.Context) {
 	paginated, info := pagination.Paginate(dbs, ctx.Pagination)
 
 	for _, db := range paginated {
-		rb.AddItem(db.Name, ui.Muted(fmt.Sprintf("ID: %s", db.UUID)))
+		replication, region := db.ReadReplication, db.Region
+		if replication == "" {
+			replication = "disabled"
+		}
+		if db.DetailsUnknown {
+			replication, region = "unknown", "unknown"
+		}
+		icb := response.NewItemContent().
+			Add("ID:", ui.Muted(db.UUID))
+		if region != "" {
+			icb.Add("Location:", ui.Text(region))
+		}
+		if db.Jurisdiction != "" {
+			icb.Add("Jurisdiction:", ui.Text(db.Jurisdiction))
+		}
+		icb.Add("Replication:", ui.Text(replication))
+		rb.AddItem(db.Name, icb.String())
 	}
 
 	if len(paginated) > 0 {
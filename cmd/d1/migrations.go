@@ -0,0 +1,150 @@
+package d1
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"dario.lol/cf/internal/executor"
+	"github.com/spf13/cobra"
+)
+
+// Migration is a numbered SQL file in the migrations directory, an entry in
+// the tracking table, or both.
+type Migration struct {
+	Name      string `json:"name"`
+	Path      string `json:"path,omitempty"`
+	Applied   bool   `json:"applied"`
+	AppliedAt string `json:"applied_at,omitempty"`
+}
+
+var (
+	migrationFileRe = regexp.MustCompile(`^(\d+)_.+\.sql$`)
+	identifierRe    = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+)
+
+var migrationsKey = executor.NewKey[[]Migration]("d1Migrations")
+
+var migrationsCmd = &cobra.Command{
+	Use:   "migrations",
+	Short: "Manage D1 schema migrations",
+	Long: `Manage numbered SQL migration files and apply them to a D1 database.
+
+Migrations live in a directory (default ./migrations) as files named like 0001_create_users.sql. Applied migrations are recorded in a tracking table (default d1_migrations), compatible with wrangler.`,
+}
+
+func init() {
+	migrationsCmd.PersistentFlags().String("dir", "migrations", "Directory containing migration files")
+	migrationsCmd.PersistentFlags().String("table", "d1_migrations", "Table used to track applied migrations")
+	D1Cmd.AddCommand(migrationsCmd)
+}
+
+// localMigrations returns the migration files in dir ordered by number.
+func localMigrations(dir string) ([]Migration, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error reading migrations directory: %w", err)
+	}
+
+	var migrations []Migration
+	for _, entry := range entries {
+		if entry.IsDir() || !migrationFileRe.MatchString(entry.Name()) {
+			continue
+		}
+		migrations = append(migrations, Migration{
+			Name: entry.Name(),
+			Path: filepath.Join(dir, entry.Name()),
+		})
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrationNumber(migrations[i].Name) < migrationNumber(migrations[j].Name)
+	})
+	return migrations, nil
+}
+
+func migrationNumber(name string) int {
+	m := migrationFileRe.FindStringSubmatch(name)
+	if m == nil {
+		return 0
+	}
+	n, _ := strconv.Atoi(m[1])
+	return n
+}
+
+func migrationsTable(ctx *executor.Context) (string, error) {
+	table, _ := ctx.Cmd.Flags().GetString("table")
+	if !identifierRe.MatchString(table) {
+		return "", fmt.Errorf("invalid --table %q", table)
+	}
+	return table, nil
+}
+
+// loadMigrations merges the local migration files with the tracking table,
+// creating the table if it does not exist yet.
+func loadMigrations(ctx *executor.Context, _ chan<- string) ([]Migration, error) {
+	dir, _ := ctx.Cmd.Flags().GetString("dir")
+	table, err := migrationsTable(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	migrations, err := localMigrations(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	dbID := executor.Get(ctx, executor.D1DatabaseIDKey)
+	results, err := runQuery(ctx, dbID, fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	name TEXT UNIQUE,
+	applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+SELECT name, applied_at FROM %s ORDER BY id`, table, table), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error reading migrations table: %w", err)
+	}
+
+	index := make(map[string]int, len(migrations))
+	for i, m := range migrations {
+		index[m.Name] = i
+	}
+	if len(results) == 0 {
+		return migrations, nil
+	}
+	for _, row := range results[len(results)-1].Results {
+		fields, ok := row.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _ := fields["name"].(string)
+		appliedAt, _ := fields["applied_at"].(string)
+		if i, ok := index[name]; ok {
+			migrations[i].Applied = true
+			migrations[i].AppliedAt = appliedAt
+			continue
+		}
+		migrations = append(migrations, Migration{Name: name, Applied: true, AppliedAt: appliedAt})
+	}
+	return migrations, nil
+}
+
+func pendingMigrations(migrations []Migration) []Migration {
+	var pending []Migration
+	for _, m := range migrations {
+		if !m.Applied {
+			pending = append(pending, m)
+		}
+	}
+	return pending
+}
+
+func quoteSQLString(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
@@ -0,0 +1,92 @@
+package d1
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"dario.lol/cf/internal/executor"
+	"dario.lol/cf/internal/flags"
+	"dario.lol/cf/internal/ui"
+	"dario.lol/cf/internal/ui/response"
+	"github.com/spf13/cobra"
+)
+
+var appliedMigrationsKey = executor.NewKey[[]string]("d1AppliedMigrations")
+
+var migrationsApplyCmd = &cobra.Command{
+	Use:   "apply <database>",
+	Short: "Apply pending migrations in order",
+	Long:  "Apply pending migrations in order. Each file runs as one batch together with its tracking row, so a failing migration leaves no partial changes behind; later migrations are not attempted.",
+	Args:  cobra.ExactArgs(1),
+	Run: executor.New().
+		WithClient().
+		WithAccountID().
+		WithD1Database().
+		Step(executor.NewStep(migrationsKey, "Checking migrations").Func(loadMigrations)).
+		WithConfirmationFunc(func(ctx *executor.Context) string {
+			pending := pendingMigrations(executor.Get(ctx, migrationsKey))
+			if len(pending) == 0 {
+				return ""
+			}
+			return fmt.Sprintf("Apply %d pending migration(s) to %s?", len(pending), executor.Get(ctx, executor.D1DatabaseNameKey))
+		}).
+		Step(executor.NewStep(appliedMigrationsKey, "Applying migrations").Func(applyMigrations)).
+		Display(printApplyMigrations).
+		Run(),
+}
+
+func init() {
+	flags.RegisterConfirmation(migrationsApplyCmd)
+	migrationsCmd.AddCommand(migrationsApplyCmd)
+}
+
+func applyMigrations(ctx *executor.Context, progress chan<- string) ([]string, error) {
+	table, err := migrationsTable(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pending := pendingMigrations(executor.Get(ctx, migrationsKey))
+	dbID := executor.Get(ctx, executor.D1DatabaseIDKey)
+
+	var applied []string
+	for i, m := range pending {
+		progress <- fmt.Sprintf("Applying %s (%d/%d)", m.Name, i+1, len(pending))
+
+		data, err := os.ReadFile(m.Path)
+		if err != nil {
+			return applied, fmt.Errorf("error reading %s: %w", m.Path, err)
+		}
+		statements := splitStatements(string(data))
+		sqls := make([]string, 0, len(statements)+1)
+		for _, stmt := range statements {
+			sqls = append(sqls, stmt.SQL)
+		}
+		sqls = append(sqls, fmt.Sprintf("INSERT INTO %s (name) VALUES (%s)", table, quoteSQLString(m.Name)))
+
+		if _, err := runQuery(ctx, dbID, strings.Join(sqls, ";\n"), nil); err != nil {
+			return applied, fmt.Errorf("migration %s failed and was rolled back (%d applied before it): %w", m.Name, len(applied), err)
+		}
+		applied = append(applied, m.Name)
+	}
+	return applied, nil
+}
+
+func printApplyMigrations(ctx *executor.Context) {
+	rb := response.New()
+	if ctx.Error != nil {
+		rb.Error("Error applying migrations", ctx.Error).Display()
+		return
+	}
+
+	name := executor.Get(ctx, executor.D1DatabaseNameKey)
+	applied := executor.Get(ctx, appliedMigrationsKey)
+	if len(applied) == 0 {
+		rb.FooterSuccessf("No pending migrations, %s is up to date %s", name, ui.Muted(fmt.Sprintf("(took %v)", ctx.Duration))).Display()
+		return
+	}
+
+	rb.AddItem("Applied", ui.BulletList(applied)).
+		FooterSuccessf("Applied %d migration(s) to %s %s", len(applied), name, ui.Muted(fmt.Sprintf("(took %v)", ctx.Duration))).
+		Display()
+}
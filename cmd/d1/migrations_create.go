@@ -0,0 +1,77 @@
+package d1
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"dario.lol/cf/internal/executor"
+	"dario.lol/cf/internal/ui"
+	"dario.lol/cf/internal/ui/response"
+	"github.com/spf13/cobra"
+)
+
+var nonWordRe = regexp.MustCompile(`[^a-z0-9]+`)
+
+var createdMigrationKey = executor.NewKey[string]("d1CreatedMigration")
+
+var migrationsCreateCmd = &cobra.Command{
+	Use:     "create <name>",
+	Short:   "Create the next numbered migration file",
+	Example: `  cf d1 migrations create add_users_table`,
+	Args:    cobra.ExactArgs(1),
+	Run: executor.New().
+		Step(executor.NewStep(createdMigrationKey, "Creating migration").Func(createMigration).Silent()).
+		Display(printCreateMigration).
+		Run(),
+}
+
+func init() {
+	migrationsCmd.AddCommand(migrationsCreateCmd)
+}
+
+func createMigration(ctx *executor.Context, _ chan<- string) (string, error) {
+	dir, _ := ctx.Cmd.Flags().GetString("dir")
+
+	slug := strings.Trim(nonWordRe.ReplaceAllString(strings.ToLower(ctx.Args[0]), "_"), "_")
+	if slug == "" {
+		return "", fmt.Errorf("invalid migration name %q", ctx.Args[0])
+	}
+
+	migrations, err := localMigrations(dir)
+	if err != nil {
+		return "", err
+	}
+	next := 1
+	if len(migrations) > 0 {
+		next = migrationNumber(migrations[len(migrations)-1].Name) + 1
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("error creating migrations directory: %w", err)
+	}
+	path := filepath.Join(dir, fmt.Sprintf("%04d_%s.sql", next, slug))
+	header := fmt.Sprintf("-- Migration number: %04d \t %s\n", next, time.Now().UTC().Format(time.RFC3339))
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return "", fmt.Errorf("error creating migration file: %w", err)
+	}
+	defer f.Close()
+	if _, err := f.WriteString(header); err != nil {
+		return "", fmt.Errorf("error writing migration file: %w", err)
+	}
+	return path, nil
+}
+
+func printCreateMigration(ctx *executor.Context) {
+	rb := response.New()
+	if ctx.Error != nil {
+		rb.Error("Error creating migration", ctx.Error).Display()
+		return
+	}
+	rb.FooterSuccessf("Created migration %s %s", executor.Get(ctx, createdMigrationKey), ui.Muted(fmt.Sprintf("(took %v)", ctx.Duration))).Display()
+}
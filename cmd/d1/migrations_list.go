@@ -0,0 +1,61 @@
+package d1
+
+import (
+	"fmt"
+
+	"dario.lol/cf/internal/executor"
+	"dario.lol/cf/internal/ui"
+	"dario.lol/cf/internal/ui/response"
+	"github.com/spf13/cobra"
+)
+
+var migrationsListCmd = &cobra.Command{
+	Use:   "list <database>",
+	Short: "List applied and pending migrations",
+	Args:  cobra.ExactArgs(1),
+	Run: executor.New().
+		WithClient().
+		WithAccountID().
+		WithD1Database().
+		Step(executor.NewStep(migrationsKey, "Checking migrations").Func(loadMigrations)).
+		Display(printListMigrations).
+		Run(),
+}
+
+func init() {
+	migrationsCmd.AddCommand(migrationsListCmd)
+}
+
+func printListMigrations(ctx *executor.Context) {
+	rb := response.New().Title("D1 Migrations")
+	if ctx.Error != nil {
+		rb.Error("Error listing migrations", ctx.Error).Display()
+		return
+	}
+
+	migrations := executor.Get(ctx, migrationsKey)
+	for _, m := range migrations {
+		icb := response.NewItemContent()
+		switch {
+		case m.Applied && m.Path == "":
+			icb.Add("Status:", ui.Warning("Applied (file missing locally)"))
+		case m.Applied:
+			icb.Add("Status:", ui.Success("Applied"))
+		default:
+			icb.Add("Status:", ui.Text("Pending"))
+		}
+		if m.AppliedAt != "" {
+			icb.Add("Applied At:", ui.Text(m.AppliedAt))
+		}
+		rb.AddItem(m.Name, icb.String())
+	}
+
+	if len(migrations) == 0 {
+		rb.NoItemsMessage("No migrations found")
+		rb.Display()
+		return
+	}
+
+	pending := len(pendingMigrations(migrations))
+	rb.FooterSuccessf("%d migration(s), %d pending on %s %s", len(migrations), pending, executor.Get(ctx, executor.D1DatabaseNameKey), ui.Muted(fmt.Sprintf("(took %v)", ctx.Duration))).Display()
+}
@@ -0,0 +1,72 @@
+package d1
+
+import (
+	"context"
+	"fmt"
+
+	"dario.lol/cf/internal/executor"
+	"dario.lol/cf/internal/ui"
+	"dario.lol/cf/internal/ui/response"
+	cf "github.com/cloudflare/cloudflare-go/v6"
+	"github.com/cloudflare/cloudflare-go/v6/d1"
+	"github.com/spf13/cobra"
+)
+
+var replicationDatabaseKey = executor.NewKey[*d1.D1]("d1ReplicationDatabase")
+
+var replicationCmd = &cobra.Command{
+	Use:   "replication",
+	Short: "Manage read replication of D1 databases",
+	Long:  "Read replication lets D1 create read-only replicas around the world. Queries only use replicas when sent through the Sessions API from a Worker.",
+}
+
+var replicationEnableCmd = &cobra.Command{
+	Use:   "enable <database>",
+	Short: "Enable automatic read replicas",
+	Args:  cobra.ExactArgs(1),
+	Run:   replicationRunner(d1.DatabaseEditParamsReadReplicationModeAuto),
+}
+
+var replicationDisableCmd = &cobra.Command{
+	Use:   "disable <database>",
+	Short: "Disable read replicas",
+	Long:  "Disable read replication. It can take a few hours for existing replicas to be removed.",
+	Args:  cobra.ExactArgs(1),
+	Run:   replicationRunner(d1.DatabaseEditParamsReadReplicationModeDisabled),
+}
+
+func init() {
+	replicationCmd.AddCommand(replicationEnableCmd)
+	replicationCmd.AddCommand(replicationDisableCmd)
+	D1Cmd.AddCommand(replicationCmd)
+}
+
+func replicationRunner(mode d1.DatabaseEditParamsReadReplicationMode) func(*cobra.Command, []string) {
+	return executor.New().
+		WithClient().
+		WithAccountID().
+		WithD1Database().
+		Step(executor.NewStep(replicationDatabaseKey, "Updating read replication").Func(func(ctx *executor.Context, _ chan<- string) (*d1.D1, error) {
+			return ctx.Client.D1.Database.Edit(context.Background(), executor.Get(ctx, executor.D1DatabaseIDKey), d1.DatabaseEditParams{
+				AccountID: cf.F(ctx.AccountID),
+				ReadReplication: cf.F(d1.DatabaseEditParamsReadReplication{
+					Mode: cf.F(mode),
+				}),
+			})
+		})).
+		Invalidates(func(ctx *executor.Context) []string {
+			return []string{"d1:databases:list"}
+		}).
+		Display(printReplication).
+		Run()
+}
+
+func printReplication(ctx *executor.Context) {
+	rb := response.New()
+	if ctx.Error != nil {
+		rb.Error("Error updating read replication", ctx.Error).Display()
+		return
+	}
+	db := executor.Get(ctx, replicationDatabaseKey)
+	rb.FooterSuccessf("Read replication for %s is now %s %s", db.Name, db.ReadReplication.Mode, ui.Muted(fmt.Sprintf("(took %v)", ctx.Duration))).Display()
+}
@@ -0,0 +1,476 @@
+package d1
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"dario.lol/cf/internal/executor"
+	"dario.lol/cf/internal/ui"
+	"dario.lol/cf/internal/ui/response"
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/cloudflare/cloudflare-go/v6/d1"
+	"github.com/spf13/cobra"
+)
+
+const (
+	shellHistoryFile  = "d1_history"
+	shellHistoryLimit = 1000
+	shellScrollStep   = 8
+)
+
+const shellHelp = `.tables           List tables
+.schema [table]   Show CREATE statements, optionally for one table
+.help             Show this help
+.quit             Exit the shell
+
+End a statement with ; to run it. Statements can span several lines.
+Use up/down for history and shift+left/right to scroll wide results.`
+
+var shellPromptStyle = lipgloss.NewStyle().Foreground(ui.C.Primary500).Bold(true)
+
+var shellQueriesKey = executor.NewKey[int]("d1ShellQueries")
+
+var shellCmd = &cobra.Command{
+	Use:   "shell <database>",
+	Short: "Open an interactive SQL shell for a D1 database",
+	Long:  "Open an interactive SQL shell. The database is resolved once, so each query costs a single API request.\n\n" + shellHelp,
+	Args:  cobra.ExactArgs(1),
+	Run: executor.New().
+		WithClient().
+		WithAccountID().
+		WithD1Database().
+		Step(executor.NewStep(shellQueriesKey, "Starting shell").Func(runShell).Silent()).
+		Display(printShellExit).
+		Run(),
+}
+
+func init() {
+	D1Cmd.AddCommand(shellCmd)
+}
+
+type shellResultMsg struct {
+	output string
+}
+
+type shellModel struct {
+	ctx     *executor.Context
+	dbID    string
+	name    string
+	input   textinput.Model
+	spinner spinner.Model
+
+	lines   []string
+	history []string
+	histPos int
+	draft   string
+
+	running bool
+	result  string
+	offset  int
+	width   int
+
+	queries  int
+	quitting bool
+}
+
+func runShell(ctx *executor.Context, _ chan<- string) (int, error) {
+	input := ui.StyledTextInput()
+	input.Focus()
+
+	history := loadShellHistory()
+	m := &shellModel{
+		ctx:     ctx,
+		dbID:    executor.Get(ctx, executor.D1DatabaseIDKey),
+		name:    executor.Get(ctx, executor.D1DatabaseNameKey),
+		input:   input,
+		spinner: ui.StyledSpinner(),
+		history: history,
+		histPos: len(history),
+		width:   80,
+		result:  ui.Muted(fmt.Sprintf("Connected to %s. Type .help for help.", executor.Get(ctx, executor.D1DatabaseNameKey))),
+	}
+
+	final, err := tea.NewProgram(m).Run()
+	if err != nil {
+		return 0, err
+	}
+	saveShellHistory(m.history)
+	return final.(*shellModel).queries, nil
+}
+
+func (m *shellModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (m *shellModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.input.Width = msg.Width - len(m.prompt()) - 1
+		return m, nil
+
+	case spinner.TickMsg:
+		if !m.running {
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+
+	case shellResultMsg:
+		m.running = false
+		m.result = msg.output
+		m.offset = 0
+		return m, nil
+
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+func (m *shellModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		if len(m.lines) > 0 || m.input.Value() != "" {
+			m.lines = nil
+			m.input.SetValue("")
+			return m, nil
+		}
+		return m.quit()
+	case "ctrl+d":
+		if len(m.lines) == 0 && m.input.Value() == "" {
+			return m.quit()
+		}
+		return m, nil
+	case "shift+left":
+		m.offset = max(0, m.offset-shellScrollStep)
+		return m, nil
+	case "shift+right":
+		if m.offset+m.width < maxLineWidth(m.result) {
+			m.offset += shellScrollStep
+		}
+		return m, nil
+	case "up":
+		if len(m.lines) == 0 && m.histPos > 0 {
+			if m.histPos == len(m.history) {
+				m.draft = m.input.Value()
+			}
+			m.histPos--
+			m.input.SetValue(m.history[m.histPos])
+			m.input.CursorEnd()
+		}
+		return m, nil
+	case "down":
+		if len(m.lines) == 0 && m.histPos < len(m.history) {
+			m.histPos++
+			if m.histPos == len(m.history) {
+				m.input.SetValue(m.draft)
+			} else {
+				m.input.SetValue(m.history[m.histPos])
+			}
+			m.input.CursorEnd()
+		}
+		return m, nil
+	case "enter":
+		if m.running {
+			return m, nil
+		}
+		return m.submit()
+	}
+
+	if m.running {
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+// submit either buffers the current line or runs the buffered statement,
+// first moving the previous result and the echoed input into scrollback.
+func (m *shellModel) submit() (tea.Model, tea.Cmd) {
+	line := m.input.Value()
+	m.input.SetValue("")
+
+	if len(m.lines) == 0 && strings.HasPrefix(strings.TrimSpace(line), ".") {
+		meta := strings.TrimSpace(line)
+		m.addHistory(meta)
+		if meta == ".quit" || meta == ".exit" {
+			return m.quit()
+		}
+		return m.run(shellPromptStyle.Render(m.prompt())+meta, m.metaCommand(meta))
+	}
+
+	m.lines = append(m.lines, line)
+	sql := strings.Join(m.lines, "\n")
+	if strings.TrimSpace(sql) == "" {
+		m.lines = nil
+		return m, nil
+	}
+	if !statementComplete(sql) {
+		return m, nil
+	}
+
+	echo := m.renderLines()
+	m.lines = nil
+	m.addHistory(sql)
+	m.queries++
+	return m, tea.Sequence(m.commit(echo), m.start(m.queryCmd(sql)))
+}
+
+func (m *shellModel) run(echo string, cmd tea.Cmd) (tea.Model, tea.Cmd) {
+	return m, tea.Sequence(m.commit(echo), m.start(cmd))
+}
+
+// commit prints the current result and echo above the live view so they
+// stay in the terminal's scrollback.
+func (m *shellModel) commit(echo string) tea.Cmd {
+	out := m.visibleResult()
+	if out != "" {
+		out += "\n"
+	}
+	m.result = ""
+	return tea.Println(out + echo)
+}
+
+func (m *shellModel) start(cmd tea.Cmd) tea.Cmd {
+	m.running = true
+	return tea.Batch(m.spinner.Tick, cmd)
+}
+
+func (m *shellModel) quit() (tea.Model, tea.Cmd) {
+	m.quitting = true
+	return m, tea.Quit
+}
+
+func (m *shellModel) addHistory(entry string) {
+	if n := len(m.history); n == 0 || m.history[n-1] != entry {
+		m.history = append(m.history, entry)
+	}
+	m.histPos = len(m.history)
+	m.draft = ""
+}
+
+func (m *shellModel) metaCommand(meta string) tea.Cmd {
+	fields := strings.Fields(meta)
+	switch fields[0] {
+	case ".help":
+		return func() tea.Msg { return shellResultMsg{output: shellHelp} }
+	case ".tables":
+		return m.metaQueryCmd(`SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT GLOB 'sqlite_*' AND name NOT GLOB '_cf_*' ORDER BY name`, nil, "No tables", func(values []string) string {
+			return strings.Join(values, "  ")
+		})
+	case ".schema":
+		sql := `SELECT sql FROM sqlite_master WHERE sql IS NOT NULL AND name NOT GLOB 'sqlite_*' AND name NOT GLOB '_cf_*'`
+		var params []string
+		if len(fields) > 1 {
+			sql += ` AND tbl_name = ?1`
+			params = []string{fields[1]}
+		}
+		return m.metaQueryCmd(sql+` ORDER BY tbl_name, type DESC, name`, params, "No schema found", func(values []string) string {
+			return strings.Join(values, ";\n") + ";"
+		})
+	}
+	return func() tea.Msg {
+		return shellResultMsg{output: ui.Error(fmt.Sprintf("Unknown command %s, type .help for help", fields[0]))}
+	}
+}
+
+// metaQueryCmd runs sql and formats the first column of every row.
+func (m *shellModel) metaQueryCmd(sql string, params []string, empty string, format func([]string) string) tea.Cmd {
+	return func() tea.Msg {
+		results, err := runQuery(m.ctx, m.dbID, sql, params)
+		if err != nil {
+			return shellResultMsg{output: ui.ErrorMessage("Query failed", err)}
+		}
+		var values []string
+		for _, res := range results {
+			for _, row := range res.Results {
+				fields, ok := row.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				for _, v := range fields {
+					values = append(values, fmt.Sprintf("%v", v))
+				}
+			}
+		}
+		if len(values) == 0 {
+			return shellResultMsg{output: ui.Muted(empty)}
+		}
+		return shellResultMsg{output: format(values)}
+	}
+}
+
+func (m *shellModel) queryCmd(sql string) tea.Cmd {
+	return func() tea.Msg {
+		start := time.Now()
+		results, err := runQuery(m.ctx, m.dbID, sql, nil)
+		elapsed := time.Since(start).Round(time.Millisecond)
+		if err != nil {
+			return shellResultMsg{output: ui.ErrorMessage("Query failed", err) + "\n" + ui.Muted(fmt.Sprintf("(took %v)", elapsed))}
+		}
+		return shellResultMsg{output: renderShellResults(results, elapsed)}
+	}
+}
+
+func renderShellResults(results []d1.QueryResult, elapsed time.Duration) string {
+	var sb strings.Builder
+	for i, res := range results {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		if !res.Success {
+			sb.WriteString(ui.Error("Query failed") + "\n")
+			continue
+		}
+		if len(res.Results) > 0 {
+			sb.WriteString(renderQueryTable(res, 0) + "\n")
+		}
+		sb.WriteString(ui.Muted(fmt.Sprintf("%d row(s) returned, %s", len(res.Results), metaSummary(res.Meta))) + "\n")
+	}
+	sb.WriteString(ui.Muted(fmt.Sprintf("(took %v)", elapsed)))
+	return sb.String()
+}
+
+func (m *shellModel) prompt() string {
+	return m.name + "> "
+}
+
+// continuation is the prompt for the second and later lines of a statement,
+// aligned with the main prompt.
+func (m *shellModel) continuation() string {
+	return strings.Repeat(" ", max(0, len(m.prompt())-5)) + "...> "
+}
+
+func (m *shellModel) renderLines() string {
+	var sb strings.Builder
+	for i, line := range m.lines {
+		if i == 0 {
+			sb.WriteString(shellPromptStyle.Render(m.prompt()))
+		} else {
+			sb.WriteString("\n" + ui.Muted(m.continuation()))
+		}
+		sb.WriteString(line)
+	}
+	return sb.String()
+}
+
+// visibleResult clips the last result to the terminal width at the current
+// horizontal scroll offset.
+func (m *shellModel) visibleResult() string {
+	if m.result == "" {
+		return ""
+	}
+	lines := strings.Split(m.result, "\n")
+	for i, line := range lines {
+		lines[i] = ansi.Cut(line, m.offset, m.offset+m.width)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (m *shellModel) View() string {
+	var sb strings.Builder
+	if out := m.visibleResult(); out != "" {
+		sb.WriteString(out + "\n")
+		if total := maxLineWidth(m.result); total > m.width {
+			sb.WriteString(ui.Muted(fmt.Sprintf("columns %d-%d of %d, shift+left/right to scroll", m.offset+1, min(total, m.offset+m.width), total)) + "\n")
+		}
+	}
+	if m.quitting {
+		return sb.String()
+	}
+
+	if len(m.lines) > 0 {
+		sb.WriteString(m.renderLines() + "\n")
+	}
+	switch {
+	case m.running:
+		sb.WriteString(fmt.Sprintf("%s Running query...", m.spinner.View()))
+	case len(m.lines) > 0:
+		sb.WriteString(ui.Muted(m.continuation()) + m.input.View())
+	default:
+		sb.WriteString(shellPromptStyle.Render(m.prompt()) + m.input.View())
+	}
+	return sb.String()
+}
+
+func maxLineWidth(s string) int {
+	width := 0
+	for _, line := range strings.Split(s, "\n") {
+		width = max(width, ansi.StringWidth(line))
+	}
+	return width
+}
+
+func shellHistoryPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".cloudflare-cli", shellHistoryFile), nil
+}
+
+// loadShellHistory reads history stored as one JSON string per line, so
+// multi-line statements survive the round trip.
+func loadShellHistory() []string {
+	path, err := shellHistoryPath()
+	if err != nil {
+		return nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	var history []string
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var entry string
+		if json.Unmarshal(scanner.Bytes(), &entry) == nil {
+			history = append(history, entry)
+		}
+	}
+	return history
+}
+
+func saveShellHistory(history []string) {
+	path, err := shellHistoryPath()
+	if err != nil {
+		return
+	}
+	if len(history) > shellHistoryLimit {
+		history = history[len(history)-shellHistoryLimit:]
+	}
+	var sb strings.Builder
+	for _, entry := range history {
+		data, _ := json.Marshal(entry)
+		sb.Write(data)
+		sb.WriteByte('\n')
+	}
+	_ = os.WriteFile(path, []byte(sb.String()), 0o600)
+}
+
+func printShellExit(ctx *executor.Context) {
+	rb := response.New()
+	if ctx.Error != nil {
+		rb.Error("Error running shell", ctx.Error).Display()
+		return
+	}
+	rb.FooterSuccessf("Closed shell for %s after %d query(s) %s", executor.Get(ctx, executor.D1DatabaseNameKey), executor.Get(ctx, shellQueriesKey), ui.Muted(fmt.Sprintf("(took %v)", ctx.Duration))).Display()
+}
@@ -0,0 +1,155 @@
+package d1
+
+import (
+	"strings"
+)
+
+// Statement is a single SQL statement and the line it starts on in its source.
+type Statement struct {
+	SQL  string
+	Line int
+}
+
+// splitStatements splits a SQL script on semicolons, ignoring those inside
+// string literals, quoted identifiers, comments and CREATE TRIGGER bodies.
+// Statements that consist only of comments are dropped.
+func splitStatements(sql string) []Statement {
+	statements, _ := scanStatements(sql)
+	return statements
+}
+
+// statementComplete reports whether sql ends with a terminated statement,
+// i.e. whether a shell should run it rather than wait for more input.
+func statementComplete(sql string) bool {
+	statements, complete := scanStatements(sql)
+	return complete && len(statements) > 0
+}
+
+func scanStatements(sql string) ([]Statement, bool) {
+	var statements []Statement
+	line := 1
+
+	start, startLine := -1, 0
+	var words []string
+	trigger := false
+	depth := 0
+	openComment := false
+
+	mark := func(i int) {
+		if start < 0 {
+			start, startLine = i, line
+		}
+	}
+	flush := func(end int) {
+		if start >= 0 {
+			if s := strings.TrimSpace(sql[start:end]); s != "" {
+				statements = append(statements, Statement{SQL: s, Line: startLine})
+			}
+		}
+		start, words, trigger, depth = -1, nil, false, 0
+	}
+	// skip moves past sql[i:j], counting the newlines it contains.
+	skip := func(i, j int) int {
+		line += strings.Count(sql[i:j], "\n")
+		return j - 1
+	}
+
+	for i := 0; i < len(sql); i++ {
+		c := sql[i]
+		switch {
+		case c == '\n':
+			line++
+		case c == ' ' || c == '\t' || c == '\r' || c == '\f':
+		case c == '-' && i+1 < len(sql) && sql[i+1] == '-':
+			j := strings.IndexByte(sql[i:], '\n')
+			if j < 0 {
+				j = len(sql) - i
+			}
+			i = skip(i, i+j)
+		case c == '/' && i+1 < len(sql) && sql[i+1] == '*':
+			j := strings.Index(sql[i+2:], "*/")
+			if j < 0 {
+				j = len(sql)
+				openComment = true
+			} else {
+				j += i + 4
+			}
+			i = skip(i, j)
+		case c == '\'' || c == '"' || c == '`':
+			mark(i)
+			i = skip(i, quotedEnd(sql, i, c))
+		case c == '[':
+			mark(i)
+			j := strings.IndexByte(sql[i:], ']')
+			if j < 0 {
+				j = len(sql)
+			} else {
+				j += i + 1
+			}
+			i = skip(i, j)
+		case c == ';':
+			if trigger && depth > 0 {
+				continue
+			}
+			flush(i)
+		case isWordByte(c):
+			mark(i)
+			j := i
+			for j < len(sql) && isWordByte(sql[j]) {
+				j++
+			}
+			word := strings.ToUpper(sql[i:j])
+			if len(words) < 3 {
+				words = append(words, word)
+				trigger = isCreateTrigger(words)
+			}
+			if trigger {
+				switch word {
+				case "BEGIN", "CASE":
+					depth++
+				case "END":
+					if depth > 0 {
+						depth--
+					}
+				}
+			}
+			i = j - 1
+		default:
+			mark(i)
+		}
+	}
+	complete := start < 0 && !openComment
+	flush(len(sql))
+
+	return statements, complete
+}
+
+// quotedEnd returns the index just past the quote opened at sql[i], treating
+// a doubled quote character as an escaped one.
+func quotedEnd(sql string, i int, quote byte) int {
+	for j := i + 1; j < len(sql); j++ {
+		if sql[j] != quote {
+			continue
+		}
+		if j+1 < len(sql) && sql[j+1] == quote {
+			j++
+			continue
+		}
+		return j + 1
+	}
+	return len(sql)
+}
+
+func isWordByte(c byte) bool {
+	return c == '_' || c == '$' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c >= 0x80
+}
+
+func isCreateTrigger(words []string) bool {
+	if len(words) < 2 || words[0] != "CREATE" {
+		return false
+	}
+	if words[1] == "TRIGGER" {
+		return true
+	}
+	return len(words) == 3 && (words[1] == "TEMP" || words[1] == "TEMPORARY") && words[2] == "TRIGGER"
+}
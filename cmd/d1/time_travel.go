@@ -0,0 +1,189 @@
+package d1
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"dario.lol/cf/internal/executor"
+	"dario.lol/cf/internal/flags"
+	"dario.lol/cf/internal/ui"
+	"dario.lol/cf/internal/ui/response"
+	"github.com/cloudflare/cloudflare-go/v6/option"
+	"github.com/spf13/cobra"
+)
+
+// TimeTravelResult is the result of the time_travel endpoints, which the SDK
+// does not cover.
+type TimeTravelResult struct {
+	Bookmark         string `json:"bookmark"`
+	PreviousBookmark string `json:"previous_bookmark,omitempty"`
+	Message          string `json:"message,omitempty"`
+}
+
+var timeTravelKey = executor.NewKey[*TimeTravelResult]("d1TimeTravel")
+
+var timeTravelCmd = &cobra.Command{
+	Use:   "time-travel",
+	Short: "Inspect and restore D1 databases to a point in time",
+	Long:  "Time Travel keeps 30 days of history (7 on the free plan). A bookmark identifies the state of a database at a point in time and can be restored to.",
+}
+
+var timeTravelInfoCmd = &cobra.Command{
+	Use:   "info <database>",
+	Short: "Show the bookmark for the current state or a point in time",
+	Example: `  cf d1 time-travel info my-db
+  cf d1 time-travel info my-db --timestamp 2025-06-01T12:00:00Z
+  cf d1 time-travel info my-db --timestamp 2h`,
+	Args: cobra.ExactArgs(1),
+	Run: executor.New().
+		WithClient().
+		WithAccountID().
+		WithD1Database().
+		Step(executor.NewStep(timeTravelKey, "Fetching bookmark").Func(getBookmark)).
+		Display(printTimeTravelInfo).
+		Run(),
+}
+
+var timeTravelRestoreCmd = &cobra.Command{
+	Use:   "restore <database>",
+	Short: "Restore a database to a bookmark or point in time",
+	Long:  "Restore a database to a bookmark or point in time. The restore itself is recorded, so it can be undone by restoring the previous bookmark it prints.",
+	Example: `  cf d1 time-travel restore my-db --timestamp 2025-06-01T12:00:00Z
+  cf d1 time-travel restore my-db --timestamp 30m
+  cf d1 time-travel restore my-db --bookmark 00000085-0000024c-00004c6d-8e61117bf38d7adb71b934ebbf891683`,
+	Args: cobra.ExactArgs(1),
+	Run: executor.New().
+		WithClient().
+		WithAccountID().
+		WithD1Database().
+		WithConfirmationFunc(func(ctx *executor.Context) string {
+			target, _ := ctx.Cmd.Flags().GetString("bookmark")
+			if target == "" {
+				target, _ = ctx.Cmd.Flags().GetString("timestamp")
+			}
+			return fmt.Sprintf("Restore %s to %s? Changes made after that point will be lost.", executor.Get(ctx, executor.D1DatabaseNameKey), target)
+		}).
+		Step(executor.NewStep(timeTravelKey, "Restoring database").Func(restoreDatabase)).
+		Display(printTimeTravelRestore).
+		Run(),
+}
+
+func init() {
+	timeTravelInfoCmd.Flags().String("timestamp", "", "Point in time as RFC 3339, Unix seconds or a duration ago, e.g. 2h (default: now)")
+	timeTravelRestoreCmd.Flags().String("timestamp", "", "Point in time as RFC 3339, Unix seconds or a duration ago, e.g. 2h")
+	timeTravelRestoreCmd.Flags().String("bookmark", "", "Bookmark to restore to")
+	timeTravelRestoreCmd.MarkFlagsOneRequired("timestamp", "bookmark")
+	timeTravelRestoreCmd.MarkFlagsMutuallyExclusive("timestamp", "bookmark")
+	flags.RegisterConfirmation(timeTravelRestoreCmd)
+
+	timeTravelCmd.AddCommand(timeTravelInfoCmd)
+	timeTravelCmd.AddCommand(timeTravelRestoreCmd)
+	D1Cmd.AddCommand(timeTravelCmd)
+}
+
+// parseTimestamp accepts RFC 3339, Unix seconds or a duration before now.
+func parseTimestamp(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if secs, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Unix(secs, 0), nil
+	}
+	if d, err := time.ParseDuration(value); err == nil && d > 0 {
+		return time.Now().Add(-d), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid --timestamp %q, expected RFC 3339 (2025-06-01T12:00:00Z), Unix seconds or a duration such as 2h", value)
+}
+
+func timeTravelPath(ctx *executor.Context, action string) string {
+	return fmt.Sprintf("accounts/%s/d1/database/%s/time_travel/%s", ctx.AccountID, executor.Get(ctx, executor.D1DatabaseIDKey), action)
+}
+
+func timestampOption(ctx *executor.Context) ([]option.RequestOption, error) {
+	value, _ := ctx.Cmd.Flags().GetString("timestamp")
+	if value == "" {
+		return nil, nil
+	}
+	t, err := parseTimestamp(value)
+	if err != nil {
+		return nil, err
+	}
+	return []option.RequestOption{option.WithQuery("timestamp", t.UTC().Format(time.RFC3339))}, nil
+}
+
+func getBookmark(ctx *executor.Context, _ chan<- string) (*TimeTravelResult, error) {
+	opts, err := timestampOption(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var env struct {
+		Result TimeTravelResult `json:"result"`
+	}
+	if err := ctx.Client.Get(context.Background(), timeTravelPath(ctx, "bookmark"), nil, &env, opts...); err != nil {
+		return nil, err
+	}
+	return &env.Result, nil
+}
+
+func restoreDatabase(ctx *executor.Context, _ chan<- string) (*TimeTravelResult, error) {
+	opts, err := timestampOption(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if bookmark, _ := ctx.Cmd.Flags().GetString("bookmark"); bookmark != "" {
+		opts = append(opts, option.WithQuery("bookmark", bookmark))
+	}
+	var env struct {
+		Result TimeTravelResult `json:"result"`
+	}
+	if err := ctx.Client.Post(context.Background(), timeTravelPath(ctx, "restore"), nil, &env, opts...); err != nil {
+		return nil, err
+	}
+	return &env.Result, nil
+}
+
+func printTimeTravelInfo(ctx *executor.Context) {
+	rb := response.New()
+	if ctx.Error != nil {
+		rb.Error("Error fetching bookmark", ctx.Error).Display()
+		return
+	}
+
+	result := executor.Get(ctx, timeTravelKey)
+	at := "now"
+	if value, _ := ctx.Cmd.Flags().GetString("timestamp"); value != "" {
+		if t, err := parseTimestamp(value); err == nil {
+			at = t.Local().Format("2006-01-02 15:04:05")
+		}
+	}
+
+	rb.AddItem(executor.Get(ctx, executor.D1DatabaseNameKey), response.NewItemContent().
+		Add("At:", ui.Text(at)).
+		Add("Bookmark:", ui.Text(result.Bookmark)).
+		String()).
+		FooterSuccessf("Fetched bookmark %s", ui.Muted(fmt.Sprintf("(took %v)", ctx.Duration))).
+		Display()
+}
+
+func printTimeTravelRestore(ctx *executor.Context) {
+	rb := response.New()
+	if ctx.Error != nil {
+		rb.Error("Error restoring database", ctx.Error).Display()
+		return
+	}
+
+	result := executor.Get(ctx, timeTravelKey)
+	name := executor.Get(ctx, executor.D1DatabaseNameKey)
+	icb := response.NewItemContent().
+		Add("Bookmark:", ui.Text(result.Bookmark))
+	if result.PreviousBookmark != "" {
+		icb.Add("Previous:", ui.Text(result.PreviousBookmark)).
+			AddRaw("\n" + ui.Muted(fmt.Sprintf("Undo with: cf d1 time-travel restore %s --bookmark %s", name, result.PreviousBookmark)))
+	}
+
+	rb.AddItem(name, icb.String()).
+		FooterSuccessf("Restored database %s %s", name, ui.Muted(fmt.Sprintf("(took %v)", ctx.Duration))).
+		Display()
+}
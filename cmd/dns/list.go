@@ -7,6 +7,7 @@ import (
 	"sort"
 	"strings"
 	"sync/atomic"
+	"time"
 
 	"dario.lol/cf/internal/cloudflare"
 	"dario.lol/cf/internal/executor"
@@ -56,7 +57,8 @@ var listCmd = &cobra.Command{
 					return ""
 				}
 				return fmt.Sprintf("zone:%s:dns", zoneID)
-			})).
+			}).
+			CacheTTL(time.Minute)).
 		Display(printDnsRecords).
 		Run(),
 }
@@ -4,11 +4,11 @@ import (
 	"context"
 	"fmt"
 
+	"dario.lol/cf/internal/cloudflare"
 	"dario.lol/cf/internal/executor"
 	"dario.lol/cf/internal/ui"
 	"dario.lol/cf/internal/ui/response"
 	cf "github.com/cloudflare/cloudflare-go/v6"
-	"github.com/cloudflare/cloudflare-go/v6/kv"
 	"github.com/cloudflare/cloudflare-go/v6/pages"
 	"github.com/spf13/cobra"
 )
@@ -22,6 +22,7 @@ var bindCmd = &cobra.Command{
 	Run: executor.New().
 		WithClient().
 		WithAccountID().
+		WithKVNamespaceArg().
 		Step(executor.NewStep(boundKVProjectKey, "Binding namespace").Func(bindNamespace)).
 		Display(printKVBindResult).
 		Run(),
@@ -35,27 +36,14 @@ func init() {
 }
 
 func bindNamespace(ctx *executor.Context, _ chan<- string) (*pages.Project, error) {
-	nsNameOrID := ctx.Args[0]
 	bindToProject, _ := ctx.Cmd.Flags().GetString("to")
 	bindBindingName, _ := ctx.Cmd.Flags().GetString("name")
 
-	pager := ctx.Client.KV.Namespaces.ListAutoPaging(context.Background(), kv.NamespaceListParams{
-		AccountID: cf.F(ctx.AccountID),
-	})
+	nsID := ctx.KVNamespace
 
-	var nsID string
-	for pager.Next() {
-		ns := pager.Current()
-		if ns.Title == nsNameOrID || ns.ID == nsNameOrID {
-			nsID = ns.ID
-			break
-		}
-	}
-	if err := pager.Err(); err != nil {
-		return nil, fmt.Errorf("error listing KV namespaces: %w", err)
-	}
-	if nsID == "" {
-		return nil, fmt.Errorf("KV namespace '%s' not found", nsNameOrID)
+	_, bindToProject, err := cloudflare.LookupPagesProject(ctx.Client, ctx.AccountID, bindToProject)
+	if err != nil {
+		return nil, err
 	}
 
 	proj, err := ctx.Client.Pages.Projects.Get(context.Background(), bindToProject, pages.ProjectGetParams{
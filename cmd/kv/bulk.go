@@ -0,0 +1,221 @@
+package kv
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"dario.lol/cf/internal/executor"
+	cf "github.com/cloudflare/cloudflare-go/v6"
+	"github.com/cloudflare/cloudflare-go/v6/kv"
+	"github.com/spf13/cobra"
+)
+
+const (
+	bulkChunkSize  = 10000
+	bulkChunkBytes = 95 * 1024 * 1024
+)
+
+// BulkEntry is a single key-value pair in the format used by the bulk
+// endpoints, `cf kv bulk put` files and `cf kv export` dumps.
+type BulkEntry struct {
+	Key           string      `json:"key"`
+	Value         string      `json:"value"`
+	Base64        bool        `json:"base64,omitempty"`
+	Expiration    int64       `json:"expiration,omitempty"`
+	ExpirationTTL int64       `json:"expiration_ttl,omitempty"`
+	Metadata      interface{} `json:"metadata,omitempty"`
+}
+
+type BulkResult struct {
+	Successful   int      `json:"successful"`
+	Unsuccessful []string `json:"unsuccessful"`
+	Skipped      int      `json:"skipped"`
+}
+
+// add records a chunk's outcome. Older API responses carry no counts, in
+// which case the whole chunk is treated as successful.
+func (r *BulkResult) add(successful float64, unsuccessful []string, chunkSize int) {
+	if successful == 0 && len(unsuccessful) == 0 {
+		successful = float64(chunkSize)
+	}
+	r.Successful += int(successful)
+	r.Unsuccessful = append(r.Unsuccessful, unsuccessful...)
+}
+
+var bulkCmd = &cobra.Command{
+	Use:   "bulk",
+	Short: "Write or delete many keys at once",
+}
+
+func init() {
+	bulkCmd.PersistentFlags().String("namespace-id", "", "The namespace title or ID")
+	KVCmd.AddCommand(bulkCmd)
+}
+
+func openInput(path string) (io.ReadCloser, error) {
+	if path == "" || path == "-" {
+		return io.NopCloser(os.Stdin), nil
+	}
+	return os.Open(path)
+}
+
+// readBulkEntries accepts either a JSON array of entries or one JSON entry per line.
+func readBulkEntries(r io.Reader) ([]BulkEntry, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	trimmed := bytes.TrimSpace(data)
+
+	var entries []BulkEntry
+	if bytes.HasPrefix(trimmed, []byte("[")) {
+		if err := json.Unmarshal(trimmed, &entries); err != nil {
+			return nil, fmt.Errorf("invalid JSON: %w", err)
+		}
+		return entries, nil
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(trimmed))
+	scanner.Buffer(make([]byte, 0, 64*1024), 32*1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+		var entry BulkEntry
+		if err := json.Unmarshal(text, &entry); err != nil {
+			return nil, fmt.Errorf("invalid JSON on line %d: %w", line, err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, scanner.Err()
+}
+
+// readBulkKeys accepts a JSON array of key names, a JSON array of entries, or
+// one key name per line.
+func readBulkKeys(r io.Reader) ([]string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	trimmed := bytes.TrimSpace(data)
+
+	if bytes.HasPrefix(trimmed, []byte("[")) {
+		var names []string
+		if err := json.Unmarshal(trimmed, &names); err == nil {
+			return names, nil
+		}
+		entries, err := readBulkEntries(bytes.NewReader(trimmed))
+		if err != nil {
+			return nil, err
+		}
+		keys := make([]string, 0, len(entries))
+		for _, entry := range entries {
+			keys = append(keys, entry.Key)
+		}
+		return keys, nil
+	}
+
+	var keys []string
+	for _, line := range strings.Split(string(trimmed), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "{") {
+			var entry BulkEntry
+			if err := json.Unmarshal([]byte(line), &entry); err != nil {
+				return nil, fmt.Errorf("invalid JSON entry %q: %w", line, err)
+			}
+			line = entry.Key
+		}
+		keys = append(keys, line)
+	}
+	return keys, nil
+}
+
+func chunkEntries(entries []BulkEntry) [][]BulkEntry {
+	var chunks [][]BulkEntry
+	var current []BulkEntry
+	size := 0
+	for _, entry := range entries {
+		entrySize := len(entry.Key) + len(entry.Value)
+		if len(current) == bulkChunkSize || (len(current) > 0 && size+entrySize > bulkChunkBytes) {
+			chunks = append(chunks, current)
+			current = nil
+			size = 0
+		}
+		current = append(current, entry)
+		size += entrySize
+	}
+	if len(current) > 0 {
+		chunks = append(chunks, current)
+	}
+	return chunks
+}
+
+func bulkPut(ctx *executor.Context, namespaceID string, entries []BulkEntry, progress chan<- string) (*BulkResult, error) {
+	result := &BulkResult{}
+	done := 0
+	for _, chunk := range chunkEntries(entries) {
+		body := make([]kv.NamespaceBulkUpdateParamsBody, 0, len(chunk))
+		for _, entry := range chunk {
+			item := kv.NamespaceBulkUpdateParamsBody{
+				Key:   cf.F(entry.Key),
+				Value: cf.F(entry.Value),
+			}
+			if entry.Base64 {
+				item.Base64 = cf.F(true)
+			}
+			if entry.ExpirationTTL > 0 {
+				item.ExpirationTTL = cf.F(float64(entry.ExpirationTTL))
+			} else if entry.Expiration > 0 {
+				item.Expiration = cf.F(float64(entry.Expiration))
+			}
+			if entry.Metadata != nil {
+				item.Metadata = cf.F(entry.Metadata)
+			}
+			body = append(body, item)
+		}
+
+		res, err := ctx.Client.KV.Namespaces.BulkUpdate(context.Background(), namespaceID, kv.NamespaceBulkUpdateParams{
+			AccountID: cf.F(ctx.AccountID),
+			Body:      body,
+		})
+		if err != nil {
+			return result, fmt.Errorf("error writing keys %d-%d: %w", done+1, done+len(chunk), err)
+		}
+		result.add(res.SuccessfulKeyCount, res.UnsuccessfulKeys, len(chunk))
+
+		done += len(chunk)
+		progress <- fmt.Sprintf("Written %d/%d keys", done, len(entries))
+	}
+	return result, nil
+}
+
+func bulkDelete(ctx *executor.Context, namespaceID string, keys []string, progress chan<- string) (*BulkResult, error) {
+	result := &BulkResult{}
+	for start := 0; start < len(keys); start += bulkChunkSize {
+		end := min(start+bulkChunkSize, len(keys))
+
+		res, err := ctx.Client.KV.Namespaces.BulkDelete(context.Background(), namespaceID, kv.NamespaceBulkDeleteParams{
+			AccountID: cf.F(ctx.AccountID),
+			Body:      keys[start:end],
+		})
+		if err != nil {
+			return result, fmt.Errorf("error deleting keys %d-%d: %w", start+1, end, err)
+		}
+		result.add(res.SuccessfulKeyCount, res.UnsuccessfulKeys, end-start)
+
+		progress <- fmt.Sprintf("Deleted %d/%d keys", end, len(keys))
+	}
+	return result, nil
+}
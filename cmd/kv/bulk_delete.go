@@ -0,0 +1,74 @@
+package kv
+
+import (
+	"fmt"
+
+	"dario.lol/cf/internal/executor"
+	"dario.lol/cf/internal/flags"
+	"dario.lol/cf/internal/ui"
+	"dario.lol/cf/internal/ui/response"
+	"github.com/spf13/cobra"
+)
+
+var bulkDeleteKeysKey = executor.NewKey[[]string]("bulkDeleteKeys")
+var bulkDeleteResultKey = executor.NewKey[*BulkResult]("bulkDeleteResult")
+
+var bulkDeleteCmd = &cobra.Command{
+	Use:   "delete <file>",
+	Short: "Delete many keys listed in a file",
+	Long:  "Delete many keys listed in a file. The file holds a JSON array of key names, a JSON array of {\"key\"} objects, or one key name per line. Use - to read from stdin.",
+	Args:  cobra.ExactArgs(1),
+	Run: executor.New().
+		WithClient().
+		WithAccountID().
+		WithKVNamespace().
+		Step(executor.NewStep(bulkDeleteKeysKey, "Reading keys").Func(readBulkDeleteKeys).Silent()).
+		WithConfirmationFunc(func(ctx *executor.Context) string {
+			return fmt.Sprintf("Are you sure you want to delete %d key(s) from namespace %s?", len(executor.Get(ctx, bulkDeleteKeysKey)), ctx.KVNamespace)
+		}).
+		Step(executor.NewStep(bulkDeleteResultKey, "Deleting keys").Func(runBulkDelete)).
+		Invalidates(func(ctx *executor.Context) []string {
+			return []string{"kv:namespace:" + ctx.KVNamespace + ":"}
+		}).
+		Display(printBulkDelete).
+		Run(),
+}
+
+func init() {
+	flags.RegisterConfirmation(bulkDeleteCmd)
+	bulkCmd.AddCommand(bulkDeleteCmd)
+}
+
+func readBulkDeleteKeys(ctx *executor.Context, _ chan<- string) ([]string, error) {
+	input, err := openInput(ctx.Args[0])
+	if err != nil {
+		return nil, fmt.Errorf("error opening file: %w", err)
+	}
+	defer input.Close()
+
+	keys, err := readBulkKeys(input)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", ctx.Args[0], err)
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no keys found in %s", ctx.Args[0])
+	}
+	return keys, nil
+}
+
+func runBulkDelete(ctx *executor.Context, progress chan<- string) (*BulkResult, error) {
+	return bulkDelete(ctx, ctx.KVNamespace, executor.Get(ctx, bulkDeleteKeysKey), progress)
+}
+
+func printBulkDelete(ctx *executor.Context) {
+	rb := response.New()
+	if ctx.Error != nil {
+		rb.Error("Error deleting keys", ctx.Error).Display()
+		return
+	}
+	result := executor.Get(ctx, bulkDeleteResultKey)
+	if len(result.Unsuccessful) > 0 {
+		rb.AddItem("Failed Keys", ui.BulletList(result.Unsuccessful))
+	}
+	rb.FooterSuccessf("Successfully deleted %d key(s) %s", result.Successful, ui.Muted(fmt.Sprintf("(took %v)", ctx.Duration))).Display()
+}
@@ -0,0 +1,64 @@
+package kv
+
+import (
+	"fmt"
+
+	"dario.lol/cf/internal/executor"
+	"dario.lol/cf/internal/ui"
+	"dario.lol/cf/internal/ui/response"
+	"github.com/spf13/cobra"
+)
+
+var bulkPutResultKey = executor.NewKey[*BulkResult]("bulkPutResult")
+
+var bulkPutCmd = &cobra.Command{
+	Use:   "put <file.json>",
+	Short: "Write many key-value pairs from a JSON file",
+	Long:  "Write many key-value pairs from a JSON file. The file holds an array of {\"key\", \"value\", \"base64\", \"expiration\", \"expiration_ttl\", \"metadata\"} objects, or one such object per line. Use - to read from stdin.",
+	Args:  cobra.ExactArgs(1),
+	Run: executor.New().
+		WithClient().
+		WithAccountID().
+		WithKVNamespace().
+		Step(executor.NewStep(bulkPutResultKey, "Writing keys").Func(runBulkPut)).
+		Invalidates(func(ctx *executor.Context) []string {
+			return []string{"kv:namespace:" + ctx.KVNamespace + ":"}
+		}).
+		Display(printBulkPut).
+		Run(),
+}
+
+func init() {
+	bulkCmd.AddCommand(bulkPutCmd)
+}
+
+func runBulkPut(ctx *executor.Context, progress chan<- string) (*BulkResult, error) {
+	input, err := openInput(ctx.Args[0])
+	if err != nil {
+		return nil, fmt.Errorf("error opening file: %w", err)
+	}
+	defer input.Close()
+
+	entries, err := readBulkEntries(input)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", ctx.Args[0], err)
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("no entries found in %s", ctx.Args[0])
+	}
+
+	return bulkPut(ctx, ctx.KVNamespace, entries, progress)
+}
+
+func printBulkPut(ctx *executor.Context) {
+	rb := response.New()
+	if ctx.Error != nil {
+		rb.Error("Error writing keys", ctx.Error).Display()
+		return
+	}
+	result := executor.Get(ctx, bulkPutResultKey)
+	if len(result.Unsuccessful) > 0 {
+		rb.AddItem("Failed Keys", ui.BulletList(result.Unsuccessful))
+	}
+	rb.FooterSuccessf("Successfully wrote %d key(s) %s", result.Successful, ui.Muted(fmt.Sprintf("(took %v)", ctx.Duration))).Display()
+}
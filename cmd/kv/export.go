@@ -0,0 +1,145 @@
+package kv
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync/atomic"
+	"unicode/utf8"
+
+	"dario.lol/cf/internal/executor"
+	"dario.lol/cf/internal/ui"
+	"dario.lol/cf/internal/ui/response"
+	"github.com/alitto/pond/v2"
+	cf "github.com/cloudflare/cloudflare-go/v6"
+	"github.com/cloudflare/cloudflare-go/v6/kv"
+	"github.com/spf13/cobra"
+)
+
+var exportEntriesKey = executor.NewKey[[]BulkEntry]("exportEntries")
+
+var exportCmd = &cobra.Command{
+	Use:   "export <namespace_name_or_id>",
+	Short: "Export every key in a namespace as JSON lines",
+	Long:  "Export every key in a namespace, including metadata and expirations, as one JSON object per line. The output can be fed back into `cf kv import` or `cf kv bulk put`.",
+	Args:  cobra.ExactArgs(1),
+	Run: executor.New().
+		WithClient().
+		WithAccountID().
+		WithKVNamespaceArg().
+		Step(executor.NewStep(exportEntriesKey, "Exporting keys").Func(exportNamespace)).
+		Display(printExport).
+		Run(),
+}
+
+func init() {
+	exportCmd.Flags().StringP("output", "o", "", "Write the export to a file instead of stdout")
+	exportCmd.Flags().String("prefix", "", "Only export keys starting with this prefix")
+	KVCmd.AddCommand(exportCmd)
+}
+
+func exportNamespace(ctx *executor.Context, progress chan<- string) ([]BulkEntry, error) {
+	namespaceID := ctx.KVNamespace
+	prefix, _ := ctx.Cmd.Flags().GetString("prefix")
+
+	params := kv.NamespaceKeyListParams{
+		AccountID: cf.F(ctx.AccountID),
+		Limit:     cf.F(float64(maxKeysPerPage)),
+	}
+	if prefix != "" {
+		params.Prefix = cf.F(prefix)
+	}
+
+	var keys []kv.Key
+	pager := ctx.Client.KV.Namespaces.Keys.ListAutoPaging(context.Background(), namespaceID, params)
+	for pager.Next() {
+		keys = append(keys, pager.Current())
+		if len(keys)%maxKeysPerPage == 0 {
+			progress <- fmt.Sprintf("Listing keys (%d found)", len(keys))
+		}
+	}
+	if err := pager.Err(); err != nil {
+		return nil, fmt.Errorf("error listing keys: %w", err)
+	}
+
+	pool := pond.NewResultPool[BulkEntry](10)
+	group := pool.NewGroup()
+
+	var completed atomic.Int32
+	for _, key := range keys {
+		key := key
+		group.SubmitErr(func() (BulkEntry, error) {
+			entry, err := exportKey(ctx, namespaceID, key)
+			if err != nil {
+				return BulkEntry{}, err
+			}
+			c := completed.Add(1)
+			progress <- fmt.Sprintf("Exported %d/%d keys", c, len(keys))
+			return entry, nil
+		})
+	}
+
+	return group.Wait()
+}
+
+func exportKey(ctx *executor.Context, namespaceID string, key kv.Key) (BulkEntry, error) {
+	resp, err := ctx.Client.KV.Namespaces.Values.Get(context.Background(), namespaceID, key.Name, kv.NamespaceValueGetParams{
+		AccountID: cf.F(ctx.AccountID),
+	})
+	if err != nil {
+		return BulkEntry{}, fmt.Errorf("error reading key %s: %w", key.Name, err)
+	}
+	defer resp.Body.Close()
+
+	value, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return BulkEntry{}, fmt.Errorf("error reading key %s: %w", key.Name, err)
+	}
+
+	entry := BulkEntry{
+		Key:        key.Name,
+		Expiration: int64(key.Expiration),
+		Metadata:   key.Metadata,
+	}
+	if utf8.Valid(value) {
+		entry.Value = string(value)
+	} else {
+		entry.Value = base64.StdEncoding.EncodeToString(value)
+		entry.Base64 = true
+	}
+	return entry, nil
+}
+
+func printExport(ctx *executor.Context) {
+	if ctx.Error != nil {
+		response.New().Error("Error exporting namespace", ctx.Error).Display()
+		return
+	}
+
+	entries := executor.Get(ctx, exportEntriesKey)
+
+	var out io.Writer = os.Stdout
+	if output, _ := ctx.Cmd.Flags().GetString("output"); output != "" {
+		file, err := os.Create(output)
+		if err != nil {
+			response.New().Error("Error creating output file", err).Display()
+			return
+		}
+		defer file.Close()
+		out = file
+	}
+
+	encoder := json.NewEncoder(out)
+	encoder.SetEscapeHTML(false)
+	for _, entry := range entries {
+		if err := encoder.Encode(entry); err != nil {
+			response.New().Error("Error writing export", err).Display()
+			return
+		}
+	}
+
+	fmt.Fprintln(os.Stderr, ui.Success(fmt.Sprintf("Exported %d key(s) from namespace %s (%s) %s", len(entries), executor.Get(ctx, executor.KVNamespaceTitleKey), ctx.KVNamespace, ui.Muted(fmt.Sprintf("(took %v)", ctx.Duration)))))
+}
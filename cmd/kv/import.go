@@ -0,0 +1,89 @@
+package kv
+
+import (
+	"fmt"
+	"time"
+
+	"dario.lol/cf/internal/executor"
+	"dario.lol/cf/internal/ui"
+	"dario.lol/cf/internal/ui/response"
+	"github.com/spf13/cobra"
+)
+
+var importResultKey = executor.NewKey[*BulkResult]("importResult")
+
+var importCmd = &cobra.Command{
+	Use:   "import <namespace_name_or_id> [file]",
+	Short: "Import keys from a `cf kv export` dump",
+	Long:  "Import keys, including metadata and expirations, from a `cf kv export` dump. Reads from stdin when no file is given. Keys that expire within the next minute are skipped.",
+	Args:  cobra.RangeArgs(1, 2),
+	Run: executor.New().
+		WithClient().
+		WithAccountID().
+		WithKVNamespaceArg().
+		Step(executor.NewStep(importResultKey, "Importing keys").Func(importNamespace)).
+		Invalidates(func(ctx *executor.Context) []string {
+			return []string{"kv:namespace:" + ctx.KVNamespace + ":"}
+		}).
+		Display(printImport).
+		Run(),
+}
+
+func init() {
+	KVCmd.AddCommand(importCmd)
+}
+
+func importNamespace(ctx *executor.Context, progress chan<- string) (*BulkResult, error) {
+	path := "-"
+	if len(ctx.Args) > 1 {
+		path = ctx.Args[1]
+	}
+
+	input, err := openInput(path)
+	if err != nil {
+		return nil, fmt.Errorf("error opening file: %w", err)
+	}
+	defer input.Close()
+
+	entries, err := readBulkEntries(input)
+	if err != nil {
+		return nil, fmt.Errorf("error reading dump: %w", err)
+	}
+
+	cutoff := time.Now().Add(time.Duration(minExpirationTTL) * time.Second).Unix()
+	valid := make([]BulkEntry, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Expiration > 0 && entry.Expiration <= cutoff {
+			continue
+		}
+		valid = append(valid, entry)
+	}
+
+	result := &BulkResult{}
+	if len(valid) > 0 {
+		result, err = bulkPut(ctx, ctx.KVNamespace, valid, progress)
+		if err != nil {
+			return nil, err
+		}
+	}
+	result.Skipped = len(entries) - len(valid)
+	return result, nil
+}
+
+func printImport(ctx *executor.Context) {
+	rb := response.New()
+	if ctx.Error != nil {
+		rb.Error("Error importing namespace", ctx.Error).Display()
+		return
+	}
+	result := executor.Get(ctx, importResultKey)
+	if len(result.Unsuccessful) > 0 {
+		rb.AddItem("Failed Keys", ui.BulletList(result.Unsuccessful))
+	}
+	footer := fmt.Sprintf("Imported %d key(s) into namespace %s (%s)", result.Successful, executor.Get(ctx, executor.KVNamespaceTitleKey), ctx.KVNamespace)
+	if result.Skipped > 0 {
+		footer += " " + ui.Muted(fmt.Sprintf("(%d expired key(s) skipped)", result.Skipped))
+	}
+	footer += " " + ui.Muted(fmt.Sprintf("(took %v)", ctx.Duration))
+	rb.FooterSuccess(footer).Display()
+}
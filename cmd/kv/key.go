@@ -14,7 +14,7 @@ var keyCmd = &cobra.Command{
 
 func init() {
 	keyCmd.PersistentFlags().StringVar(&keyAccountID, "account-id", "", "The account ID")
-	keyCmd.PersistentFlags().StringVar(&namespaceID, "namespace-id", "", "The namespace ID")
+	keyCmd.PersistentFlags().StringVar(&namespaceID, "namespace-id", "", "The namespace title or ID")
 
 	KVCmd.AddCommand(keyCmd)
 }
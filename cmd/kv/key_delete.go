@@ -0,0 +1,56 @@
+package kv
+
+import (
+	"context"
+	"fmt"
+
+	"dario.lol/cf/internal/executor"
+	"dario.lol/cf/internal/flags"
+	"dario.lol/cf/internal/ui"
+	"dario.lol/cf/internal/ui/response"
+	cf "github.com/cloudflare/cloudflare-go/v6"
+	"github.com/cloudflare/cloudflare-go/v6/kv"
+	"github.com/spf13/cobra"
+)
+
+var deleteResultKey = executor.NewKey[bool]("deleteResult")
+
+var deleteKeyCmd = &cobra.Command{
+	Use:   "delete <key>",
+	Short: "Delete a key from a namespace",
+	Args:  cobra.ExactArgs(1),
+	Run: executor.New().
+		WithClient().
+		WithAccountID().
+		WithKVNamespace().
+		WithConfirmationFunc(func(ctx *executor.Context) string {
+			return fmt.Sprintf("Are you sure you want to delete key %s from namespace %s?", ctx.Args[0], ctx.KVNamespace)
+		}).
+		Step(executor.NewStep(deleteResultKey, "Deleting key").Func(deleteKey)).
+		Invalidates(func(ctx *executor.Context) []string {
+			return []string{"kv:namespace:" + ctx.KVNamespace + ":"}
+		}).
+		Display(printDeleteKey).
+		Run(),
+}
+
+func init() {
+	flags.RegisterConfirmation(deleteKeyCmd)
+	keyCmd.AddCommand(deleteKeyCmd)
+}
+
+func deleteKey(ctx *executor.Context, _ chan<- string) (bool, error) {
+	_, err := ctx.Client.KV.Namespaces.Values.Delete(context.Background(), ctx.KVNamespace, ctx.Args[0], kv.NamespaceValueDeleteParams{
+		AccountID: cf.F(ctx.AccountID),
+	})
+	return err == nil, err
+}
+
+func printDeleteKey(ctx *executor.Context) {
+	rb := response.New()
+	if ctx.Error != nil {
+		rb.Error("Error deleting key", ctx.Error).Display()
+		return
+	}
+	rb.FooterSuccessf("Successfully deleted key %s %s", ctx.Args[0], ui.Muted(fmt.Sprintf("(took %v)", ctx.Duration))).Display()
+}
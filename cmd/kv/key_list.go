@@ -0,0 +1,175 @@
+package kv
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"dario.lol/cf/internal/executor"
+	"dario.lol/cf/internal/ui"
+	"dario.lol/cf/internal/ui/response"
+	cf "github.com/cloudflare/cloudflare-go/v6"
+	"github.com/cloudflare/cloudflare-go/v6/kv"
+	"github.com/spf13/cobra"
+)
+
+const (
+	maxKeysPerPage = 1000
+	minKeysPerPage = 10
+)
+
+type KeyListing struct {
+	Keys   []kv.Key `json:"keys"`
+	Cursor string   `json:"cursor"`
+	// Truncated is set when keys were dropped from the last page to honour
+	// --limit, so Cursor would skip them and is left empty.
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+var keyListingKey = executor.NewKey[*KeyListing]("keyListing")
+
+var listKeyCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List keys in a namespace",
+	Args:  cobra.NoArgs,
+	Run: executor.New().
+		WithClient().
+		WithAccountID().
+		WithKVNamespace().
+		WithNoCache().
+		Step(executor.NewStep(keyListingKey, "Listing keys").
+			Func(listKeys).
+			CacheKeyFunc(func(ctx *executor.Context) string {
+				prefix, _ := ctx.Cmd.Flags().GetString("prefix")
+				cursor, _ := ctx.Cmd.Flags().GetString("cursor")
+				limit, _ := ctx.Cmd.Flags().GetInt("limit")
+				return fmt.Sprintf("kv:namespace:%s:keys:prefix=%s:cursor=%s:limit=%d", ctx.KVNamespace, prefix, cursor, limit)
+			})).
+		Display(printListKeys).
+		Run(),
+}
+
+func init() {
+	listKeyCmd.Flags().String("prefix", "", "Only list keys starting with this prefix")
+	listKeyCmd.Flags().IntP("limit", "l", 0, "Maximum number of keys to list (0 = all)")
+	listKeyCmd.Flags().String("cursor", "", "Continue listing from a cursor returned by a previous call")
+	listKeyCmd.Flags().Bool("keys-only", false, "Print only key names, one per line")
+	listKeyCmd.Flags().Bool("no-cache", false, "Bypass the cache and fetch directly from the API")
+	keyCmd.AddCommand(listKeyCmd)
+}
+
+func listKeys(ctx *executor.Context, progress chan<- string) (*KeyListing, error) {
+	prefix, _ := ctx.Cmd.Flags().GetString("prefix")
+	cursor, _ := ctx.Cmd.Flags().GetString("cursor")
+	limit, _ := ctx.Cmd.Flags().GetInt("limit")
+
+	listing := &KeyListing{}
+	for {
+		pageSize := maxKeysPerPage
+		if limit > 0 {
+			pageSize = keysPageSize(limit - len(listing.Keys))
+		}
+
+		params := kv.NamespaceKeyListParams{
+			AccountID: cf.F(ctx.AccountID),
+			Limit:     cf.F(float64(pageSize)),
+		}
+		if prefix != "" {
+			params.Prefix = cf.F(prefix)
+		}
+		if cursor != "" {
+			params.Cursor = cf.F(cursor)
+		}
+
+		page, err := ctx.Client.KV.Namespaces.Keys.List(context.Background(), ctx.KVNamespace, params)
+		if err != nil {
+			return nil, err
+		}
+
+		listing.Keys = append(listing.Keys, page.Result...)
+		cursor = page.ResultInfo.Cursors.After
+		progress <- fmt.Sprintf("Listing keys (%d fetched)", len(listing.Keys))
+
+		if limit > 0 && len(listing.Keys) > limit {
+			listing.Keys = listing.Keys[:limit]
+			listing.Truncated = true
+			break
+		}
+		if cursor == "" || len(page.Result) == 0 {
+			break
+		}
+		if limit > 0 && len(listing.Keys) >= limit {
+			listing.Cursor = cursor
+			break
+		}
+	}
+
+	return listing, nil
+}
+
+// keysPageSize returns how many keys to request when remaining are still
+// wanted. The API rejects pages below minKeysPerPage, so a page that would
+// leave fewer than that for the next request is shortened instead, and only
+// a final remainder below the minimum is over-fetched and trimmed.
+func keysPageSize(remaining int) int {
+	switch {
+	case remaining < minKeysPerPage:
+		return minKeysPerPage
+	case remaining <= maxKeysPerPage:
+		return remaining
+	case remaining-maxKeysPerPage < minKeysPerPage:
+		return remaining - minKeysPerPage
+	default:
+		return maxKeysPerPage
+	}
+}
+
+func printListKeys(ctx *executor.Context) {
+	rb := response.New().Title("KV Keys")
+
+	if ctx.Error != nil {
+		rb.Error("Error listing keys", ctx.Error).Display()
+		return
+	}
+
+	listing := executor.Get(ctx, keyListingKey)
+
+	if keysOnly, _ := ctx.Cmd.Flags().GetBool("keys-only"); keysOnly {
+		for _, key := range listing.Keys {
+			fmt.Println(key.Name)
+		}
+		return
+	}
+
+	for _, key := range listing.Keys {
+		icb := response.NewItemContent()
+		if key.Expiration > 0 {
+			icb.Add("Expires:", ui.Text(time.Unix(int64(key.Expiration), 0).Format("2006-01-02 15:04:05")))
+		} else {
+			icb.Add("Expires:", ui.Muted("Never"))
+		}
+		if key.Metadata != nil {
+			metadata, err := json.Marshal(key.Metadata)
+			if err == nil {
+				icb.Add("Metadata:", ui.Text(string(metadata)))
+			}
+		}
+		rb.AddItem(key.Name, icb.String())
+	}
+
+	if len(listing.Keys) == 0 {
+		rb.NoItemsMessage("No keys found")
+	} else {
+		footer := fmt.Sprintf("Showing %d key(s)", len(listing.Keys))
+		if listing.Cursor != "" {
+			footer += " " + ui.Muted(fmt.Sprintf("(more available, continue with --cursor %s)", listing.Cursor))
+		} else if listing.Truncated {
+			footer += " " + ui.Muted("(more available, raise --limit to see them)")
+		}
+		footer += " " + ui.Muted(fmt.Sprintf("(took %v)", ctx.Duration))
+		rb.FooterSuccess(footer)
+	}
+
+	rb.Display()
+}
@@ -2,7 +2,12 @@ package kv
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
 
 	"dario.lol/cf/internal/executor"
 	"dario.lol/cf/internal/ui"
@@ -12,12 +17,15 @@ import (
 	"github.com/spf13/cobra"
 )
 
+const minExpirationTTL = 60
+
 var putResultKey = executor.NewKey[bool]("putResult")
 
 var putKeyCmd = &cobra.Command{
-	Use:   "put <key> <value>",
+	Use:   "put <key> [value]",
 	Short: "Put a key-value pair into a namespace",
-	Args:  cobra.ExactArgs(2),
+	Long:  "Put a key-value pair into a namespace. The value is taken from the argument, from --file, or read from stdin when neither is given.",
+	Args:  cobra.RangeArgs(1, 2),
 	Run: executor.New().
 		WithClient().
 		WithAccountID().
@@ -31,20 +39,91 @@ var putKeyCmd = &cobra.Command{
 }
 
 func init() {
+	putKeyCmd.Flags().StringP("file", "f", "", "Read the value from a file instead of the command line")
+	putKeyCmd.Flags().Int("ttl", 0, "Expire the key after this many seconds (minimum 60)")
+	putKeyCmd.Flags().String("expiration", "", "Expire the key at an absolute time (UNIX seconds or RFC 3339)")
+	putKeyCmd.Flags().String("metadata", "", "Arbitrary JSON metadata to associate with the key")
+	putKeyCmd.MarkFlagsMutuallyExclusive("ttl", "expiration")
 	keyCmd.AddCommand(putKeyCmd)
 }
 
 func putKey(ctx *executor.Context, _ chan<- string) (bool, error) {
 	keyName := ctx.Args[0]
-	value := ctx.Args[1]
 
-	_, err := ctx.Client.KV.Namespaces.Values.Update(context.Background(), ctx.KVNamespace, keyName, kv.NamespaceValueUpdateParams{
+	value, err := readValue(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	params := kv.NamespaceValueUpdateParams{
 		AccountID: cf.F(ctx.AccountID),
 		Value:     cf.F(value),
-	})
+	}
+
+	ttl, _ := ctx.Cmd.Flags().GetInt("ttl")
+	if ttl != 0 {
+		if ttl < minExpirationTTL {
+			return false, fmt.Errorf("--ttl must be at least %d seconds", minExpirationTTL)
+		}
+		params.ExpirationTTL = cf.F(float64(ttl))
+	}
+
+	if expiration, _ := ctx.Cmd.Flags().GetString("expiration"); expiration != "" {
+		expiresAt, err := parseExpiration(expiration)
+		if err != nil {
+			return false, err
+		}
+		params.Expiration = cf.F(float64(expiresAt.Unix()))
+	}
+
+	if rawMetadata, _ := ctx.Cmd.Flags().GetString("metadata"); rawMetadata != "" {
+		var metadata interface{}
+		if err := json.Unmarshal([]byte(rawMetadata), &metadata); err != nil {
+			return false, fmt.Errorf("--metadata must be valid JSON: %w", err)
+		}
+		params.Metadata = cf.F(metadata)
+	}
+
+	_, err = ctx.Client.KV.Namespaces.Values.Update(context.Background(), ctx.KVNamespace, keyName, params)
 	return err == nil, err
 }
 
+func readValue(ctx *executor.Context) (string, error) {
+	file, _ := ctx.Cmd.Flags().GetString("file")
+	if file != "" && len(ctx.Args) > 1 {
+		return "", fmt.Errorf("cannot use both a value argument and --file")
+	}
+
+	if len(ctx.Args) > 1 {
+		return ctx.Args[1], nil
+	}
+
+	if file != "" && file != "-" {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return "", fmt.Errorf("error reading value file: %w", err)
+		}
+		return string(data), nil
+	}
+
+	data, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return "", fmt.Errorf("error reading value from stdin: %w", err)
+	}
+	return string(data), nil
+}
+
+func parseExpiration(value string) (time.Time, error) {
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Unix(seconds, 0), nil
+	}
+	expiresAt, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --expiration %q: expected UNIX seconds or RFC 3339 time", value)
+	}
+	return expiresAt, nil
+}
+
 func printPutKey(ctx *executor.Context) {
 	rb := response.New()
 	if ctx.Error != nil {
@@ -0,0 +1,71 @@
+package kv
+
+import (
+	"context"
+	"fmt"
+
+	"dario.lol/cf/internal/cloudflare"
+	"dario.lol/cf/internal/config"
+	"dario.lol/cf/internal/executor"
+	"dario.lol/cf/internal/flags"
+	"dario.lol/cf/internal/ui"
+	"dario.lol/cf/internal/ui/response"
+	cf "github.com/cloudflare/cloudflare-go/v6"
+	"github.com/cloudflare/cloudflare-go/v6/kv"
+	"github.com/spf13/cobra"
+)
+
+var deletedNamespaceKey = executor.NewKey[bool]("deletedNamespace")
+
+var deleteNamespaceCmd = &cobra.Command{
+	Use:   "delete <name-or-id>",
+	Short: "Delete a KV namespace and all of its keys",
+	Args:  cobra.ExactArgs(1),
+	Run: executor.New().
+		WithClient().
+		WithAccountID().
+		WithKVNamespaceArg().
+		WithConfirmationFunc(func(ctx *executor.Context) string {
+			return fmt.Sprintf("Are you sure you want to delete namespace %s (%s) and all of its keys?", executor.Get(ctx, executor.KVNamespaceTitleKey), ctx.KVNamespace)
+		}).
+		Step(executor.NewStep(deletedNamespaceKey, "Deleting namespace").Func(deleteNamespace)).
+		Invalidates(func(ctx *executor.Context) []string {
+			return []string{"kv:namespaces:list", "kv:namespace:" + ctx.KVNamespace + ":"}
+		}).
+		Display(printDeleteNamespace).
+		Run(),
+}
+
+func init() {
+	flags.RegisterConfirmation(deleteNamespaceCmd)
+	namespaceCmd.AddCommand(deleteNamespaceCmd)
+}
+
+func deleteNamespace(ctx *executor.Context, _ chan<- string) (bool, error) {
+	_, err := ctx.Client.KV.Namespaces.Delete(context.Background(), ctx.KVNamespace, kv.NamespaceDeleteParams{
+		AccountID: cf.F(ctx.AccountID),
+	})
+	if err != nil {
+		return false, err
+	}
+
+	cloudflare.DeleteID(cloudflare.KVNamespaceCacheKey(ctx.AccountID, executor.Get(ctx, executor.KVNamespaceTitleKey)))
+	cloudflare.DeleteID(cloudflare.KVNamespaceCacheKey(ctx.AccountID, ctx.KVNamespace))
+
+	if config.Cfg.KVNamespaceID == ctx.KVNamespace {
+		config.Cfg.KVNamespaceID = ""
+		if err := config.SaveConfig(); err != nil {
+			return false, fmt.Errorf("namespace deleted, but failed to clear the active namespace: %w", err)
+		}
+	}
+	return true, nil
+}
+
+func printDeleteNamespace(ctx *executor.Context) {
+	rb := response.New()
+	if ctx.Error != nil {
+		rb.Error("Error deleting namespace", ctx.Error).Display()
+		return
+	}
+	rb.FooterSuccessf("Successfully deleted namespace %s (%s) %s", executor.Get(ctx, executor.KVNamespaceTitleKey), ctx.KVNamespace, ui.Muted(fmt.Sprintf("(took %v)", ctx.Duration))).Display()
+}
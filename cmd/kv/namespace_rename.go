@@ -0,0 +1,61 @@
+package kv
+
+import (
+	"context"
+	"fmt"
+
+	"dario.lol/cf/internal/cloudflare"
+	"dario.lol/cf/internal/executor"
+	"dario.lol/cf/internal/ui"
+	"dario.lol/cf/internal/ui/response"
+	cf "github.com/cloudflare/cloudflare-go/v6"
+	"github.com/cloudflare/cloudflare-go/v6/kv"
+	"github.com/spf13/cobra"
+)
+
+var renamedNamespaceKey = executor.NewKey[*kv.Namespace]("renamedNamespace")
+
+var renameNamespaceCmd = &cobra.Command{
+	Use:   "rename <name-or-id> <new-title>",
+	Short: "Rename a KV namespace",
+	Args:  cobra.ExactArgs(2),
+	Run: executor.New().
+		WithClient().
+		WithAccountID().
+		WithKVNamespaceArg().
+		Step(executor.NewStep(renamedNamespaceKey, "Renaming namespace").Func(renameNamespace)).
+		Invalidates(func(ctx *executor.Context) []string {
+			return []string{"kv:namespaces:list"}
+		}).
+		Display(printRenameNamespace).
+		Run(),
+}
+
+func init() {
+	namespaceCmd.AddCommand(renameNamespaceCmd)
+}
+
+func renameNamespace(ctx *executor.Context, _ chan<- string) (*kv.Namespace, error) {
+	ns, err := ctx.Client.KV.Namespaces.Update(context.Background(), ctx.KVNamespace, kv.NamespaceUpdateParams{
+		AccountID: cf.F(ctx.AccountID),
+		Title:     cf.F(ctx.Args[1]),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	cloudflare.DeleteID(cloudflare.KVNamespaceCacheKey(ctx.AccountID, executor.Get(ctx, executor.KVNamespaceTitleKey)))
+	cloudflare.SetID(cloudflare.KVNamespaceCacheKey(ctx.AccountID, ns.Title), ns.ID)
+	cloudflare.SetID(cloudflare.KVNamespaceCacheKey(ctx.AccountID, ns.ID), ns.Title)
+	return ns, nil
+}
+
+func printRenameNamespace(ctx *executor.Context) {
+	rb := response.New()
+	if ctx.Error != nil {
+		rb.Error("Error renaming namespace", ctx.Error).Display()
+		return
+	}
+	ns := executor.Get(ctx, renamedNamespaceKey)
+	rb.FooterSuccessf("Renamed namespace %s to %s (%s) %s", executor.Get(ctx, executor.KVNamespaceTitleKey), ns.Title, ns.ID, ui.Muted(fmt.Sprintf("(took %v)", ctx.Duration))).Display()
+}
@@ -1,14 +1,12 @@
 package kv
 
 import (
-	"context"
 	"fmt"
 
 	"dario.lol/cf/internal/config"
 	"dario.lol/cf/internal/executor"
 	"dario.lol/cf/internal/ui"
 	"dario.lol/cf/internal/ui/response"
-	cf "github.com/cloudflare/cloudflare-go/v6"
 	"github.com/cloudflare/cloudflare-go/v6/kv"
 	"github.com/spf13/cobra"
 )
@@ -22,6 +20,7 @@ var switchNamespaceCmd = &cobra.Command{
 	Run: executor.New().
 		WithClient().
 		WithAccountID().
+		WithKVNamespaceArg().
 		Step(executor.NewStep(switchedNamespaceKey, "Verifying namespace").Func(runNamespaceSwitch)).
 		Display(printNamespaceSwitch).
 		Run(),
@@ -32,34 +31,9 @@ func init() {
 }
 
 func runNamespaceSwitch(ctx *executor.Context, _ chan<- string) (kv.Namespace, error) {
-	input := ctx.Args[0]
-	var selectedNamespace kv.Namespace
-
-	pager := ctx.Client.KV.Namespaces.ListAutoPaging(context.Background(), kv.NamespaceListParams{
-		AccountID: cf.F(ctx.AccountID),
-	})
-
-	var matches []kv.Namespace
-	for pager.Next() {
-		ns := pager.Current()
-		if ns.ID == input || ns.Title == input {
-			matches = append(matches, ns)
-		}
-	}
-	if err := pager.Err(); err != nil {
-		return kv.Namespace{}, fmt.Errorf("failed to list namespaces: %w", err)
-	}
-
-	if len(matches) == 1 {
-		selectedNamespace = matches[0]
-	} else if len(matches) > 1 {
-		msg := fmt.Sprintf("Multiple namespaces found matching '%s':", input)
-		for _, ns := range matches {
-			msg += fmt.Sprintf("\n - %s (%s)", ns.Title, ns.ID)
-		}
-		return kv.Namespace{}, fmt.Errorf("%s\nPlease specify the Namespace ID.", msg)
-	} else {
-		return kv.Namespace{}, fmt.Errorf("namespace not found with title or ID '%s'", input)
+	selectedNamespace := kv.Namespace{
+		ID:    ctx.KVNamespace,
+		Title: executor.Get(ctx, executor.KVNamespaceTitleKey),
 	}
 
 	config.Cfg.KVNamespaceID = selectedNamespace.ID
@@ -4,12 +4,12 @@ import (
 	"context"
 	"fmt"
 
+	"dario.lol/cf/internal/cloudflare"
 	"dario.lol/cf/internal/executor"
 	"dario.lol/cf/internal/ui"
 	"dario.lol/cf/internal/ui/response"
 	cf "github.com/cloudflare/cloudflare-go/v6"
 	"github.com/cloudflare/cloudflare-go/v6/pages"
-	"github.com/cloudflare/cloudflare-go/v6/r2"
 	"github.com/spf13/cobra"
 )
 
@@ -22,6 +22,7 @@ var bindCmd = &cobra.Command{
 	Run: executor.New().
 		WithClient().
 		WithAccountID().
+		WithR2Bucket().
 		Step(executor.NewStep(boundR2ProjectKey, "Binding bucket").Func(bindBucket)).
 		Display(printR2BindResult).
 		Run(),
@@ -35,26 +36,13 @@ func init() {
 }
 
 func bindBucket(ctx *executor.Context, _ chan<- string) (*pages.Project, error) {
-	bucketName := ctx.Args[0]
+	bucketName := executor.Get(ctx, executor.R2BucketNameKey)
 	bindToProject, _ := ctx.Cmd.Flags().GetString("to")
 	bindBindingName, _ := ctx.Cmd.Flags().GetString("name")
 
-	res, err := ctx.Client.R2.Buckets.List(context.Background(), r2.BucketListParams{
-		AccountID: cf.F(ctx.AccountID),
-	})
+	_, bindToProject, err := cloudflare.LookupPagesProject(ctx.Client, ctx.AccountID, bindToProject)
 	if err != nil {
-		return nil, fmt.Errorf("error listing R2 buckets: %w", err)
-	}
-
-	var found bool
-	for _, b := range res.Buckets {
-		if b.Name == bucketName {
-			found = true
-			break
-		}
-	}
-	if !found {
-		return nil, fmt.Errorf("bucket '%s' not found", bucketName)
+		return nil, err
 	}
 
 	proj, err := ctx.Client.Pages.Projects.Get(context.Background(), bindToProject, pages.ProjectGetParams{
@@ -0,0 +1,109 @@
+package r2
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"dario.lol/cf/internal/executor"
+	"dario.lol/cf/internal/ui"
+	"dario.lol/cf/internal/ui/response"
+	cf "github.com/cloudflare/cloudflare-go/v6"
+	"github.com/cloudflare/cloudflare-go/v6/option"
+	"github.com/cloudflare/cloudflare-go/v6/r2"
+	"github.com/spf13/cobra"
+)
+
+var (
+	corsRulesKey   = executor.NewKey[*BucketRules]("r2CORSRules")
+	corsUpdatedKey = executor.NewKey[*BucketRules]("r2CORSUpdated")
+)
+
+var corsCmd = &cobra.Command{
+	Use:   "cors",
+	Short: "Manage a bucket's CORS policy",
+}
+
+var corsGetCmd = &cobra.Command{
+	Use:   "get <bucket>",
+	Short: "Print a bucket's CORS rules as JSON",
+	Args:  cobra.ExactArgs(1),
+	Run: executor.New().
+		WithClient().
+		WithAccountID().
+		WithR2Bucket().
+		Step(executor.NewStep(corsRulesKey, "Fetching CORS rules").Func(getCORS)).
+		Display(func(ctx *executor.Context) { printRules(ctx, corsRulesKey, "Error fetching CORS rules") }).
+		Run(),
+}
+
+var corsSetCmd = &cobra.Command{
+	Use:   "set <bucket>",
+	Short: "Replace a bucket's CORS rules from a JSON file",
+	Long:  "Replace a bucket's CORS rules with the ones in a JSON file, in the format printed by `cf r2 bucket cors get`. An empty rule list removes the CORS policy.",
+	Args:  cobra.ExactArgs(1),
+	Run: executor.New().
+		WithClient().
+		WithAccountID().
+		WithR2Bucket().
+		Step(executor.NewStep(corsUpdatedKey, "Updating CORS rules").Func(setCORS)).
+		Display(printSetCORS).
+		Run(),
+}
+
+func init() {
+	registerRulesFileFlag(corsSetCmd)
+	corsCmd.AddCommand(corsGetCmd)
+	corsCmd.AddCommand(corsSetCmd)
+	bucketCmd.AddCommand(corsCmd)
+}
+
+func getCORS(ctx *executor.Context, _ chan<- string) (*BucketRules, error) {
+	res, err := ctx.Client.R2.Buckets.CORS.Get(context.Background(), executor.Get(ctx, executor.R2BucketNameKey), r2.BucketCORSGetParams{
+		AccountID: cf.F(ctx.AccountID),
+	}, jurisdictionOption(ctx)...)
+	if err != nil {
+		return nil, err
+	}
+	return parseRules(res.JSON.RawJSON())
+}
+
+func setCORS(ctx *executor.Context, _ chan<- string) (*BucketRules, error) {
+	rules, err := readRulesFile(ctx)
+	if err != nil {
+		return nil, err
+	}
+	bucket := executor.Get(ctx, executor.R2BucketNameKey)
+
+	if len(rules.Rules) == 0 {
+		_, err = ctx.Client.R2.Buckets.CORS.Delete(context.Background(), bucket, r2.BucketCORSDeleteParams{
+			AccountID: cf.F(ctx.AccountID),
+		}, jurisdictionOption(ctx)...)
+		return rules, err
+	}
+
+	body, err := json.Marshal(rules)
+	if err != nil {
+		return nil, err
+	}
+	opts := append(jurisdictionOption(ctx), option.WithRequestBody("application/json", body))
+	_, err = ctx.Client.R2.Buckets.CORS.Update(context.Background(), bucket, r2.BucketCORSUpdateParams{
+		AccountID: cf.F(ctx.AccountID),
+	}, opts...)
+	return rules, err
+}
+
+func printSetCORS(ctx *executor.Context) {
+	rb := response.New()
+	if ctx.Error != nil {
+		rb.Error("Error updating CORS rules", ctx.Error).Display()
+		return
+	}
+	rules := executor.Get(ctx, corsUpdatedKey)
+	bucket := executor.Get(ctx, executor.R2BucketNameKey)
+	if len(rules.Rules) == 0 {
+		rb.FooterSuccessf("Removed the CORS policy from bucket %s %s", bucket, ui.Muted(fmt.Sprintf("(took %v)", ctx.Duration))).Display()
+		return
+	}
+	rb.FooterSuccessf("Set %d CORS rule(s) on bucket %s %s", len(rules.Rules), bucket, ui.Muted(fmt.Sprintf("(took %v)", ctx.Duration))).Display()
+}
@@ -3,6 +3,7 @@ package r2
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"dario.lol/cf/internal/executor"
 	"dario.lol/cf/internal/ui"
@@ -12,32 +13,91 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var (
+	bucketLocations      = []string{"wnam", "enam", "weur", "eeur", "apac", "oc"}
+	bucketStorageClasses = []string{"Standard", "InfrequentAccess"}
+	bucketJurisdictions  = []string{"default", "eu", "fedramp"}
+)
+
 var createdBucketKey = executor.NewKey[*r2.Bucket]("createdBucket")
 
 var createCmd = &cobra.Command{
 	Use:   "create <name>",
 	Short: "Create a new R2 bucket",
+	Long:  "Create a new R2 bucket. A jurisdiction guarantees where the bucket's data is stored and cannot be changed later; a location is only a placement hint.",
 	Args:  cobra.ExactArgs(1),
 	Run: executor.New().
 		WithClient().
 		WithAccountID().
 		Step(executor.NewStep(createdBucketKey, "Creating bucket").Func(createBucket)).
 		Invalidates(func(ctx *executor.Context) []string {
-			return []string{"r2:buckets:list"}
+			return []string{"r2:buckets:"}
 		}).
 		Display(printCreateBucket).
 		Run(),
 }
 
 func init() {
+	createCmd.Flags().String("location", "", "Location hint for the bucket ("+strings.Join(bucketLocations, ", ")+")")
+	createCmd.Flags().String("storage-class", "", "Default storage class for new objects ("+strings.Join(bucketStorageClasses, ", ")+")")
 	bucketCmd.AddCommand(createCmd)
 }
 
+// matchOption returns the entry of options equal to value ignoring case.
+func matchOption(flag, value string, options []string) (string, error) {
+	for _, option := range options {
+		if strings.EqualFold(option, value) {
+			return option, nil
+		}
+	}
+	return "", fmt.Errorf("invalid --%s %q, expected one of %s", flag, value, strings.Join(options, ", "))
+}
+
 func createBucket(ctx *executor.Context, _ chan<- string) (*r2.Bucket, error) {
-	return ctx.Client.R2.Buckets.New(context.Background(), r2.BucketNewParams{
+	params := r2.BucketNewParams{
 		AccountID: cf.F(ctx.AccountID),
 		Name:      cf.F(ctx.Args[0]),
-	})
+	}
+
+	if location, _ := ctx.Cmd.Flags().GetString("location"); location != "" {
+		location, err := matchOption("location", location, bucketLocations)
+		if err != nil {
+			return nil, err
+		}
+		params.LocationHint = cf.F(r2.BucketNewParamsLocationHint(location))
+	}
+	if storageClass, _ := ctx.Cmd.Flags().GetString("storage-class"); storageClass != "" {
+		storageClass, err := matchOption("storage-class", storageClass, bucketStorageClasses)
+		if err != nil {
+			return nil, err
+		}
+		params.StorageClass = cf.F(r2.BucketNewParamsStorageClass(storageClass))
+	}
+	if jurisdiction, _ := ctx.Cmd.Flags().GetString("jurisdiction"); jurisdiction != "" {
+		jurisdiction, err := matchOption("jurisdiction", jurisdiction, bucketJurisdictions)
+		if err != nil {
+			return nil, err
+		}
+		params.Jurisdiction = cf.F(r2.BucketNewParamsCfR2Jurisdiction(jurisdiction))
+	}
+
+	return ctx.Client.R2.Buckets.New(context.Background(), params)
+}
+
+func bucketItem(bucket r2.Bucket) string {
+	icb := response.NewItemContent().Add("Created:", ui.Text(bucket.CreationDate))
+	if bucket.Location != "" {
+		icb.Add("Location:", ui.Text(strings.ToLower(string(bucket.Location))))
+	}
+	if bucket.StorageClass != "" {
+		icb.Add("Storage Class:", ui.Text(string(bucket.StorageClass)))
+	}
+	jurisdiction := string(bucket.Jurisdiction)
+	if jurisdiction == "" {
+		jurisdiction = "default"
+	}
+	icb.Add("Jurisdiction:", ui.Text(jurisdiction))
+	return icb.String()
 }
 
 func printCreateBucket(ctx *executor.Context) {
@@ -47,5 +107,5 @@ func printCreateBucket(ctx *executor.Context) {
 		return
 	}
 	bucket := executor.Get(ctx, createdBucketKey)
-	rb.FooterSuccessf("Successfully created bucket %s %s", bucket.Name, ui.Muted(fmt.Sprintf("(took %v)", ctx.Duration))).Display()
+	rb.AddItem(bucket.Name, bucketItem(*bucket)).FooterSuccessf("Successfully created bucket %s %s", bucket.Name, ui.Muted(fmt.Sprintf("(took %v)", ctx.Duration))).Display()
 }
@@ -0,0 +1,87 @@
+package r2
+
+import (
+	"context"
+	"fmt"
+
+	"dario.lol/cf/internal/cloudflare"
+	"dario.lol/cf/internal/executor"
+	"dario.lol/cf/internal/flags"
+	"dario.lol/cf/internal/s3"
+	"dario.lol/cf/internal/ui"
+	"dario.lol/cf/internal/ui/response"
+	cf "github.com/cloudflare/cloudflare-go/v6"
+	"github.com/cloudflare/cloudflare-go/v6/r2"
+	"github.com/spf13/cobra"
+)
+
+var (
+	bucketEmptyKey   = executor.NewKey[bool]("r2BucketEmpty")
+	deletedBucketKey = executor.NewKey[bool]("r2DeletedBucket")
+)
+
+var deleteBucketCmd = &cobra.Command{
+	Use:   "delete <bucket>",
+	Short: "Delete an empty R2 bucket",
+	Long:  "Delete an R2 bucket. The bucket must be empty; use `cf r2 object list` to see what is left in it.",
+	Args:  cobra.ExactArgs(1),
+	Run: executor.New().
+		WithClient().
+		WithAccountID().
+		WithR2Bucket().
+		Step(executor.NewStep(bucketEmptyKey, "Checking bucket is empty").Func(checkBucketEmpty)).
+		WithConfirmationFunc(func(ctx *executor.Context) string {
+			return fmt.Sprintf("Are you sure you want to delete bucket %s?", executor.Get(ctx, executor.R2BucketNameKey))
+		}).
+		Step(executor.NewStep(deletedBucketKey, "Deleting bucket").Func(deleteBucket)).
+		Invalidates(func(ctx *executor.Context) []string {
+			return []string{"r2:buckets:", bucketCacheTag(ctx)}
+		}).
+		Display(printDeleteBucket).
+		Run(),
+}
+
+func init() {
+	flags.RegisterConfirmation(deleteBucketCmd)
+	bucketCmd.AddCommand(deleteBucketCmd)
+}
+
+// checkBucketEmpty lists at most one object over the S3 API. Without R2
+// credentials the check is skipped and the API's own rejection applies.
+func checkBucketEmpty(ctx *executor.Context, _ chan<- string) (bool, error) {
+	client, err := newObjectClient(ctx, nil)
+	if err != nil {
+		return false, nil
+	}
+	bucket := executor.Get(ctx, executor.R2BucketNameKey)
+	res, err := client.ListObjects(context.Background(), bucket, s3.ListOptions{MaxKeys: 1})
+	if err != nil {
+		return false, fmt.Errorf("error checking bucket contents: %w", err)
+	}
+	if len(res.Objects) > 0 {
+		return false, fmt.Errorf("bucket %s is not empty (it contains %s and possibly more), delete its objects first", bucket, res.Objects[0].Key)
+	}
+	return true, nil
+}
+
+func deleteBucket(ctx *executor.Context, _ chan<- string) (bool, error) {
+	bucket := executor.Get(ctx, executor.R2BucketNameKey)
+	_, err := ctx.Client.R2.Buckets.Delete(context.Background(), bucket, r2.BucketDeleteParams{
+		AccountID: cf.F(ctx.AccountID),
+	}, jurisdictionOption(ctx)...)
+	if err != nil {
+		return false, err
+	}
+	jurisdiction, _ := ctx.Cmd.Flags().GetString("jurisdiction")
+	cloudflare.DeleteID(cloudflare.R2BucketCacheKey(ctx.AccountID, jurisdiction, bucket))
+	return true, nil
+}
+
+func printDeleteBucket(ctx *executor.Context) {
+	rb := response.New()
+	if ctx.Error != nil {
+		rb.Error("Error deleting bucket", ctx.Error).Display()
+		return
+	}
+	rb.FooterSuccessf("Successfully deleted bucket %s %s", executor.Get(ctx, executor.R2BucketNameKey), ui.Muted(fmt.Sprintf("(took %v)", ctx.Duration))).Display()
+}
@@ -0,0 +1,78 @@
+package r2
+
+import (
+	"context"
+	"fmt"
+
+	"dario.lol/cf/internal/executor"
+	"dario.lol/cf/internal/ui"
+	"dario.lol/cf/internal/ui/response"
+	cf "github.com/cloudflare/cloudflare-go/v6"
+	"github.com/cloudflare/cloudflare-go/v6/r2"
+	"github.com/spf13/cobra"
+)
+
+var devURLKey = executor.NewKey[*r2.BucketDomainManagedUpdateResponse]("r2DevURL")
+
+var devURLCmd = &cobra.Command{
+	Use:   "dev-url",
+	Short: "Manage public access through the bucket's r2.dev URL",
+}
+
+var devURLEnableCmd = &cobra.Command{
+	Use:   "enable <bucket>",
+	Short: "Allow public access through the r2.dev URL",
+	Long:  "Allow public access through the bucket's r2.dev URL. The r2.dev URL is rate limited and meant for development; use `cf r2 bucket domain add` for production traffic.",
+	Args:  cobra.ExactArgs(1),
+	Run:   devURLRunner(true),
+}
+
+var devURLDisableCmd = &cobra.Command{
+	Use:   "disable <bucket>",
+	Short: "Disable public access through the r2.dev URL",
+	Args:  cobra.ExactArgs(1),
+	Run:   devURLRunner(false),
+}
+
+func init() {
+	devURLCmd.AddCommand(devURLEnableCmd)
+	devURLCmd.AddCommand(devURLDisableCmd)
+	bucketCmd.AddCommand(devURLCmd)
+}
+
+func devURLRunner(enabled bool) func(cmd *cobra.Command, args []string) {
+	message := "Disabling r2.dev URL"
+	if enabled {
+		message = "Enabling r2.dev URL"
+	}
+	return executor.New().
+		WithClient().
+		WithAccountID().
+		WithR2Bucket().
+		Step(executor.NewStep(devURLKey, message).Func(func(ctx *executor.Context, _ chan<- string) (*r2.BucketDomainManagedUpdateResponse, error) {
+			return ctx.Client.R2.Buckets.Domains.Managed.Update(context.Background(), executor.Get(ctx, executor.R2BucketNameKey), r2.BucketDomainManagedUpdateParams{
+				AccountID: cf.F(ctx.AccountID),
+				Enabled:   cf.F(enabled),
+			}, jurisdictionOption(ctx)...)
+		})).
+		Invalidates(func(ctx *executor.Context) []string {
+			return []string{bucketCacheTag(ctx) + "domains"}
+		}).
+		Display(printDevURL).
+		Run()
+}
+
+func printDevURL(ctx *executor.Context) {
+	rb := response.New()
+	if ctx.Error != nil {
+		rb.Error("Error updating r2.dev URL", ctx.Error).Display()
+		return
+	}
+	res := executor.Get(ctx, devURLKey)
+	bucket := executor.Get(ctx, executor.R2BucketNameKey)
+	if res.Enabled {
+		rb.FooterSuccessf("Bucket %s is public at https://%s %s", bucket, res.Domain, ui.Muted(fmt.Sprintf("(took %v)", ctx.Duration))).Display()
+		return
+	}
+	rb.FooterSuccessf("Disabled the r2.dev URL for bucket %s %s", bucket, ui.Muted(fmt.Sprintf("(took %v)", ctx.Duration))).Display()
+}
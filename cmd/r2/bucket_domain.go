@@ -0,0 +1,219 @@
+package r2
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"dario.lol/cf/internal/cloudflare"
+	"dario.lol/cf/internal/executor"
+	"dario.lol/cf/internal/flags"
+	"dario.lol/cf/internal/ui"
+	"dario.lol/cf/internal/ui/response"
+	cf "github.com/cloudflare/cloudflare-go/v6"
+	"github.com/cloudflare/cloudflare-go/v6/r2"
+	"github.com/spf13/cobra"
+)
+
+type BucketDomains struct {
+	Custom  []r2.BucketDomainCustomListResponseDomain
+	Managed *r2.BucketDomainManagedListResponse
+}
+
+var (
+	bucketDomainsKey       = executor.NewKey[*BucketDomains]("r2BucketDomains")
+	addedBucketDomainKey   = executor.NewKey[*r2.BucketDomainCustomNewResponse]("r2AddedBucketDomain")
+	removedBucketDomainKey = executor.NewKey[string]("r2RemovedBucketDomain")
+)
+
+var domainCmd = &cobra.Command{
+	Use:   "domain",
+	Short: "Manage custom domains serving a bucket",
+}
+
+var domainAddCmd = &cobra.Command{
+	Use:   "add <bucket> <domain>",
+	Short: "Serve a bucket publicly on a custom domain",
+	Long:  "Connect a custom domain to a bucket. The domain must belong to a zone in the same account; the zone is detected from the domain unless --zone is given.",
+	Args:  cobra.ExactArgs(2),
+	Run: executor.New().
+		WithClient().
+		WithAccountID().
+		WithR2Bucket().
+		Step(executor.NewStep(addedBucketDomainKey, "Adding custom domain").Func(addBucketDomain)).
+		Invalidates(func(ctx *executor.Context) []string {
+			return []string{bucketCacheTag(ctx) + "domains"}
+		}).
+		Display(printAddBucketDomain).
+		Run(),
+}
+
+var domainListCmd = &cobra.Command{
+	Use:   "list <bucket>",
+	Short: "List the domains serving a bucket",
+	Args:  cobra.ExactArgs(1),
+	Run: executor.New().
+		WithClient().
+		WithAccountID().
+		WithR2Bucket().
+		Step(executor.NewStep(bucketDomainsKey, "Fetching domains").
+			Func(listBucketDomains).
+			CacheKeyFunc(func(ctx *executor.Context) string {
+				return bucketCacheTag(ctx) + "domains"
+			})).
+		Display(printListBucketDomains).
+		Run(),
+}
+
+var domainRemoveCmd = &cobra.Command{
+	Use:   "remove <bucket> <domain>",
+	Short: "Disconnect a custom domain from a bucket",
+	Args:  cobra.ExactArgs(2),
+	Run: executor.New().
+		WithClient().
+		WithAccountID().
+		WithR2Bucket().
+		WithConfirmationFunc(func(ctx *executor.Context) string {
+			return fmt.Sprintf("Are you sure you want to remove %s from bucket %s?", ctx.Args[1], ctx.Args[0])
+		}).
+		Step(executor.NewStep(removedBucketDomainKey, "Removing custom domain").Func(removeBucketDomain)).
+		Invalidates(func(ctx *executor.Context) []string {
+			return []string{bucketCacheTag(ctx) + "domains"}
+		}).
+		Display(printRemoveBucketDomain).
+		Run(),
+}
+
+func init() {
+	domainAddCmd.Flags().String("zone", "", "The zone name or ID the domain belongs to")
+	domainAddCmd.Flags().String("min-tls", "", "Minimum TLS version (1.0, 1.1, 1.2, 1.3)")
+	flags.RegisterConfirmation(domainRemoveCmd)
+
+	domainCmd.AddCommand(domainAddCmd)
+	domainCmd.AddCommand(domainListCmd)
+	domainCmd.AddCommand(domainRemoveCmd)
+	bucketCmd.AddCommand(domainCmd)
+}
+
+func addBucketDomain(ctx *executor.Context, _ chan<- string) (*r2.BucketDomainCustomNewResponse, error) {
+	domain := ctx.Args[1]
+	zone, _ := ctx.Cmd.Flags().GetString("zone")
+
+	var zoneID string
+	var err error
+	if zone != "" {
+		zoneID, _, err = cloudflare.LookupZone(ctx.Client, zone)
+	} else {
+		zoneID, err = zoneForDomain(ctx, domain)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	params := r2.BucketDomainCustomNewParams{
+		AccountID: cf.F(ctx.AccountID),
+		Domain:    cf.F(domain),
+		ZoneID:    cf.F(zoneID),
+		Enabled:   cf.F(true),
+	}
+	if minTLS, _ := ctx.Cmd.Flags().GetString("min-tls"); minTLS != "" {
+		params.MinTLS = cf.F(r2.BucketDomainCustomNewParamsMinTLS(minTLS))
+	}
+	return ctx.Client.R2.Buckets.Domains.Custom.New(context.Background(), executor.Get(ctx, executor.R2BucketNameKey), params, jurisdictionOption(ctx)...)
+}
+
+// zoneForDomain finds the zone a hostname belongs to by trying each parent
+// domain, longest first.
+func zoneForDomain(ctx *executor.Context, domain string) (string, error) {
+	labels := strings.Split(strings.TrimSuffix(domain, "."), ".")
+	for i := 0; i < len(labels)-1; i++ {
+		if zoneID, _, err := cloudflare.LookupZone(ctx.Client, strings.Join(labels[i:], ".")); err == nil {
+			return zoneID, nil
+		}
+	}
+	return "", fmt.Errorf("no zone found for %s in this account, pass it with --zone", domain)
+}
+
+func listBucketDomains(ctx *executor.Context, _ chan<- string) (*BucketDomains, error) {
+	bucket := executor.Get(ctx, executor.R2BucketNameKey)
+	custom, err := ctx.Client.R2.Buckets.Domains.Custom.List(context.Background(), bucket, r2.BucketDomainCustomListParams{
+		AccountID: cf.F(ctx.AccountID),
+	}, jurisdictionOption(ctx)...)
+	if err != nil {
+		return nil, err
+	}
+	managed, err := ctx.Client.R2.Buckets.Domains.Managed.List(context.Background(), bucket, r2.BucketDomainManagedListParams{
+		AccountID: cf.F(ctx.AccountID),
+	}, jurisdictionOption(ctx)...)
+	if err != nil {
+		return nil, err
+	}
+	return &BucketDomains{Custom: custom.Domains, Managed: managed}, nil
+}
+
+func removeBucketDomain(ctx *executor.Context, _ chan<- string) (string, error) {
+	res, err := ctx.Client.R2.Buckets.Domains.Custom.Delete(context.Background(), executor.Get(ctx, executor.R2BucketNameKey), ctx.Args[1], r2.BucketDomainCustomDeleteParams{
+		AccountID: cf.F(ctx.AccountID),
+	}, jurisdictionOption(ctx)...)
+	if err != nil {
+		return "", err
+	}
+	return res.Domain, nil
+}
+
+func printAddBucketDomain(ctx *executor.Context) {
+	rb := response.New()
+	if ctx.Error != nil {
+		rb.Error("Error adding custom domain", ctx.Error).Display()
+		return
+	}
+	domain := executor.Get(ctx, addedBucketDomainKey)
+	rb.FooterSuccessf("Bucket %s is now served at https://%s %s", executor.Get(ctx, executor.R2BucketNameKey), domain.Domain, ui.Muted(fmt.Sprintf("(took %v)", ctx.Duration))).Display()
+}
+
+func printListBucketDomains(ctx *executor.Context) {
+	rb := response.New().Title("Bucket Domains")
+	if ctx.Error != nil {
+		rb.Error("Error listing domains", ctx.Error).Display()
+		return
+	}
+	domains := executor.Get(ctx, bucketDomainsKey)
+
+	if domains.Managed != nil && domains.Managed.Domain != "" {
+		status := ui.Muted("Disabled")
+		if domains.Managed.Enabled {
+			status = ui.Success("Enabled")
+		}
+		rb.AddItem(domains.Managed.Domain, response.NewItemContent().
+			Add("Type:", ui.Text("r2.dev")).
+			Add("Public:", status).
+			String())
+	}
+	for _, d := range domains.Custom {
+		status := ui.Muted("Disabled")
+		if d.Enabled {
+			status = ui.Success("Enabled")
+		}
+		icb := response.NewItemContent().
+			Add("Type:", ui.Text("Custom")).
+			Add("Public:", status).
+			Add("Zone:", ui.Text(d.ZoneName)).
+			Add("Ownership:", ui.Text(string(d.Status.Ownership))).
+			Add("SSL:", ui.Text(string(d.Status.SSL)))
+		if d.MinTLS != "" {
+			icb.Add("Min TLS:", ui.Text(string(d.MinTLS)))
+		}
+		rb.AddItem(d.Domain, icb.String())
+	}
+
+	rb.FooterSuccessf("Found %d custom domain(s) %s", len(domains.Custom), ui.Muted(fmt.Sprintf("(took %v)", ctx.Duration))).Display()
+}
+
+func printRemoveBucketDomain(ctx *executor.Context) {
+	rb := response.New()
+	if ctx.Error != nil {
+		rb.Error("Error removing custom domain", ctx.Error).Display()
+		return
+	}
+	rb.FooterSuccessf("Removed %s from bucket %s %s", executor.Get(ctx, removedBucketDomainKey), executor.Get(ctx, executor.R2BucketNameKey), ui.Muted(fmt.Sprintf("(took %v)", ctx.Duration))).Display()
+}
@@ -0,0 +1,96 @@
+package r2
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"dario.lol/cf/internal/executor"
+	"dario.lol/cf/internal/ui"
+	"dario.lol/cf/internal/ui/response"
+	cf "github.com/cloudflare/cloudflare-go/v6"
+	"github.com/cloudflare/cloudflare-go/v6/option"
+	"github.com/cloudflare/cloudflare-go/v6/r2"
+	"github.com/spf13/cobra"
+)
+
+var (
+	lifecycleRulesKey   = executor.NewKey[*BucketRules]("r2LifecycleRules")
+	lifecycleUpdatedKey = executor.NewKey[*BucketRules]("r2LifecycleUpdated")
+)
+
+var lifecycleCmd = &cobra.Command{
+	Use:   "lifecycle",
+	Short: "Manage a bucket's object lifecycle rules",
+}
+
+var lifecycleGetCmd = &cobra.Command{
+	Use:   "get <bucket>",
+	Short: "Print a bucket's lifecycle rules as JSON",
+	Args:  cobra.ExactArgs(1),
+	Run: executor.New().
+		WithClient().
+		WithAccountID().
+		WithR2Bucket().
+		Step(executor.NewStep(lifecycleRulesKey, "Fetching lifecycle rules").Func(getLifecycle)).
+		Display(func(ctx *executor.Context) { printRules(ctx, lifecycleRulesKey, "Error fetching lifecycle rules") }).
+		Run(),
+}
+
+var lifecycleSetCmd = &cobra.Command{
+	Use:   "set <bucket>",
+	Short: "Replace a bucket's lifecycle rules from a JSON file",
+	Long:  "Replace a bucket's lifecycle rules with the ones in a JSON file, in the format printed by `cf r2 bucket lifecycle get`. An empty rule list removes all rules.",
+	Args:  cobra.ExactArgs(1),
+	Run: executor.New().
+		WithClient().
+		WithAccountID().
+		WithR2Bucket().
+		Step(executor.NewStep(lifecycleUpdatedKey, "Updating lifecycle rules").Func(setLifecycle)).
+		Display(printSetLifecycle).
+		Run(),
+}
+
+func init() {
+	registerRulesFileFlag(lifecycleSetCmd)
+	lifecycleCmd.AddCommand(lifecycleGetCmd)
+	lifecycleCmd.AddCommand(lifecycleSetCmd)
+	bucketCmd.AddCommand(lifecycleCmd)
+}
+
+func getLifecycle(ctx *executor.Context, _ chan<- string) (*BucketRules, error) {
+	res, err := ctx.Client.R2.Buckets.Lifecycle.Get(context.Background(), executor.Get(ctx, executor.R2BucketNameKey), r2.BucketLifecycleGetParams{
+		AccountID: cf.F(ctx.AccountID),
+	}, jurisdictionOption(ctx)...)
+	if err != nil {
+		return nil, err
+	}
+	return parseRules(res.JSON.RawJSON())
+}
+
+func setLifecycle(ctx *executor.Context, _ chan<- string) (*BucketRules, error) {
+	rules, err := readRulesFile(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := json.Marshal(rules)
+	if err != nil {
+		return nil, err
+	}
+	opts := append(jurisdictionOption(ctx), option.WithRequestBody("application/json", body))
+	_, err = ctx.Client.R2.Buckets.Lifecycle.Update(context.Background(), executor.Get(ctx, executor.R2BucketNameKey), r2.BucketLifecycleUpdateParams{
+		AccountID: cf.F(ctx.AccountID),
+	}, opts...)
+	return rules, err
+}
+
+func printSetLifecycle(ctx *executor.Context) {
+	rb := response.New()
+	if ctx.Error != nil {
+		rb.Error("Error updating lifecycle rules", ctx.Error).Display()
+		return
+	}
+	rules := executor.Get(ctx, lifecycleUpdatedKey)
+	rb.FooterSuccessf("Set %d lifecycle rule(s) on bucket %s %s", len(rules.Rules), executor.Get(ctx, executor.R2BucketNameKey), ui.Muted(fmt.Sprintf("(took %v)", ctx.Duration))).Display()
+}
@@ -3,6 +3,8 @@ package r2
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 
 	"dario.lol/cf/internal/executor"
 	"dario.lol/cf/internal/pagination"
@@ -18,30 +20,67 @@ var bucketsKey = executor.NewKey[[]r2.Bucket]("buckets")
 var listCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List R2 buckets",
+	Long:  "List R2 buckets across all jurisdictions, or only the one given with --jurisdiction.",
 	Run: executor.New().
 		WithClient().
 		WithAccountID().
 		WithPagination().
 		Step(executor.NewStep(bucketsKey, "Fetching buckets").
 			Func(listBuckets).
-			CacheKey("r2:buckets:list")).
+			CacheKeyFunc(func(ctx *executor.Context) string {
+				jurisdiction, _ := ctx.Cmd.Flags().GetString("jurisdiction")
+				return "r2:buckets:list:jurisdiction=" + jurisdiction
+			})).
 		Display(printListBuckets).
 		Run(),
 }
 
 func init() {
 	pagination.RegisterFlags(listCmd)
+	listCmd.Flags().String("location", "", "Only show buckets in this location ("+strings.Join(bucketLocations, ", ")+")")
+	listCmd.Flags().String("storage-class", "", "Only show buckets with this default storage class ("+strings.Join(bucketStorageClasses, ", ")+")")
 	bucketCmd.AddCommand(listCmd)
 }
 
+// listBuckets lists the buckets of every jurisdiction, since the API only
+// returns the ones matching the cf-r2-jurisdiction header. Jurisdictions the
+// account has no access to are skipped.
 func listBuckets(ctx *executor.Context, _ chan<- string) ([]r2.Bucket, error) {
-	res, err := ctx.Client.R2.Buckets.List(context.Background(), r2.BucketListParams{
-		AccountID: cf.F(ctx.AccountID),
-	})
-	if err != nil {
-		return nil, err
+	jurisdictions := bucketJurisdictions
+	if jurisdiction, _ := ctx.Cmd.Flags().GetString("jurisdiction"); jurisdiction != "" {
+		jurisdiction, err := matchOption("jurisdiction", jurisdiction, bucketJurisdictions)
+		if err != nil {
+			return nil, err
+		}
+		jurisdictions = []string{jurisdiction}
 	}
-	return res.Buckets, nil
+
+	var buckets []r2.Bucket
+	for _, jurisdiction := range jurisdictions {
+		params := r2.BucketListParams{
+			AccountID: cf.F(ctx.AccountID),
+			PerPage:   cf.F(1000.0),
+		}
+		if jurisdiction != "default" {
+			params.Jurisdiction = cf.F(r2.BucketListParamsCfR2Jurisdiction(jurisdiction))
+		}
+		res, err := ctx.Client.R2.Buckets.List(context.Background(), params)
+		if err != nil {
+			if jurisdiction != "default" && len(jurisdictions) > 1 {
+				continue
+			}
+			return nil, err
+		}
+		for _, bucket := range res.Buckets {
+			if bucket.Jurisdiction == "" {
+				bucket.Jurisdiction = r2.BucketJurisdiction(jurisdiction)
+			}
+			buckets = append(buckets, bucket)
+		}
+	}
+
+	sort.SliceStable(buckets, func(i, j int) bool { return buckets[i].Name < buckets[j].Name })
+	return buckets, nil
 }
 
 func printListBuckets(ctx *executor.Context) {
@@ -52,11 +91,23 @@ func printListBuckets(ctx *executor.Context) {
 		return
 	}
 
-	buckets := executor.Get(ctx, bucketsKey)
+	location, _ := ctx.Cmd.Flags().GetString("location")
+	storageClass, _ := ctx.Cmd.Flags().GetString("storage-class")
+
+	var buckets []r2.Bucket
+	for _, bucket := range executor.Get(ctx, bucketsKey) {
+		if location != "" && !strings.EqualFold(string(bucket.Location), location) {
+			continue
+		}
+		if storageClass != "" && !strings.EqualFold(string(bucket.StorageClass), storageClass) {
+			continue
+		}
+		buckets = append(buckets, bucket)
+	}
 	paginated, info := pagination.Paginate(buckets, ctx.Pagination)
 
 	for _, bucket := range paginated {
-		rb.AddItem(bucket.Name, ui.Muted(bucket.CreationDate))
+		rb.AddItem(bucket.Name, bucketItem(bucket))
 	}
 
 	if len(paginated) == 0 {
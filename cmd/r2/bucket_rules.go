@@ -0,0 +1,78 @@
+package r2
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"dario.lol/cf/internal/executor"
+	"dario.lol/cf/internal/ui/response"
+	"github.com/spf13/cobra"
+)
+
+// BucketRules is a bucket's CORS or lifecycle configuration in the API's
+// {"rules": [...]} shape, kept as raw JSON so it round-trips between get and set.
+type BucketRules struct {
+	Rules []json.RawMessage `json:"rules"`
+}
+
+func registerRulesFileFlag(cmd *cobra.Command) {
+	cmd.Flags().StringP("file", "f", "", "JSON file with the rules, either {\"rules\": [...]} or a bare array (- for stdin)")
+	cmd.MarkFlagRequired("file")
+}
+
+func readRulesFile(ctx *executor.Context) (*BucketRules, error) {
+	path, _ := ctx.Cmd.Flags().GetString("file")
+
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading rules file: %w", err)
+	}
+
+	rules := &BucketRules{}
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '[' {
+		err = json.Unmarshal(data, &rules.Rules)
+	} else {
+		err = json.Unmarshal(data, rules)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error parsing rules file: %w", err)
+	}
+	if rules.Rules == nil {
+		rules.Rules = []json.RawMessage{}
+	}
+	return rules, nil
+}
+
+func parseRules(raw string) (*BucketRules, error) {
+	rules := &BucketRules{Rules: []json.RawMessage{}}
+	if raw == "" {
+		return rules, nil
+	}
+	if err := json.Unmarshal([]byte(raw), rules); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+func printRules(ctx *executor.Context, key executor.Key[*BucketRules], errTitle string) {
+	if ctx.Error != nil {
+		response.New().Error(errTitle, ctx.Error).Display()
+		return
+	}
+	out, err := json.MarshalIndent(executor.Get(ctx, key), "", "  ")
+	if err != nil {
+		response.New().Error(errTitle, err).Display()
+		return
+	}
+	fmt.Println(string(out))
+}
@@ -0,0 +1,67 @@
+package r2
+
+import (
+	"fmt"
+	"strings"
+
+	"dario.lol/cf/internal/cloudflare"
+	"dario.lol/cf/internal/executor"
+	"dario.lol/cf/internal/s3"
+	"github.com/spf13/cobra"
+)
+
+type ObjectPath struct {
+	Bucket string
+	Key    string
+}
+
+func (p ObjectPath) String() string {
+	if p.Key == "" {
+		return p.Bucket
+	}
+	return p.Bucket + "/" + p.Key
+}
+
+var (
+	objectClientKey = executor.NewKey[*s3.Client]("r2ObjectClient")
+	objectPathKey   = executor.NewKey[ObjectPath]("r2ObjectPath")
+)
+
+var objectCmd = &cobra.Command{
+	Use:   "object",
+	Short: "Manage objects in R2 buckets",
+	Long:  "Manage objects in R2 buckets through the S3-compatible API. Credentials are derived from the API token you logged in with, or taken from CF_R2_ACCESS_KEY_ID and CF_R2_SECRET_ACCESS_KEY. Set CF_R2_ENDPOINT to use a different S3-compatible endpoint.",
+}
+
+func init() {
+	R2Cmd.AddCommand(objectCmd)
+}
+
+// parseObjectPath splits "bucket/key" (optionally prefixed with r2://) into
+// its bucket and key. The key may be empty.
+func parseObjectPath(arg string) (ObjectPath, error) {
+	arg = strings.TrimPrefix(arg, "r2://")
+	bucket, key, _ := strings.Cut(arg, "/")
+	if bucket == "" {
+		return ObjectPath{}, fmt.Errorf("invalid object path %q, expected <bucket>/<key>", arg)
+	}
+	return ObjectPath{Bucket: bucket, Key: key}, nil
+}
+
+func newObjectClient(ctx *executor.Context, _ chan<- string) (*s3.Client, error) {
+	jurisdiction, _ := ctx.Cmd.Flags().GetString("jurisdiction")
+	return cloudflare.NewR2Client(ctx.Client, ctx.AccountID, jurisdiction)
+}
+
+func resolveObjectPath(requireKey bool) func(*executor.Context, chan<- string) (ObjectPath, error) {
+	return func(ctx *executor.Context, _ chan<- string) (ObjectPath, error) {
+		path, err := parseObjectPath(ctx.Args[0])
+		if err != nil {
+			return ObjectPath{}, err
+		}
+		if requireKey && path.Key == "" {
+			return ObjectPath{}, fmt.Errorf("missing object key in %q, expected <bucket>/<key>", ctx.Args[0])
+		}
+		return path, nil
+	}
+}
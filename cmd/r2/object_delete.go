@@ -0,0 +1,51 @@
+package r2
+
+import (
+	"context"
+	"fmt"
+
+	"dario.lol/cf/internal/executor"
+	"dario.lol/cf/internal/flags"
+	"dario.lol/cf/internal/ui"
+	"dario.lol/cf/internal/ui/response"
+	"github.com/spf13/cobra"
+)
+
+var objectDeletedKey = executor.NewKey[bool]("r2ObjectDeleted")
+
+var objectDeleteCmd = &cobra.Command{
+	Use:   "delete <bucket>/<key>",
+	Short: "Delete an object",
+	Args:  cobra.ExactArgs(1),
+	Run: executor.New().
+		WithClient().
+		WithAccountID().
+		Step(executor.NewStep(objectClientKey, "Resolving R2 credentials").Func(newObjectClient).Silent()).
+		Step(executor.NewStep(objectPathKey, "Resolving object").Func(resolveObjectPath(true)).Silent()).
+		WithConfirmationFunc(func(ctx *executor.Context) string {
+			return fmt.Sprintf("Are you sure you want to delete %s?", executor.Get(ctx, objectPathKey))
+		}).
+		Step(executor.NewStep(objectDeletedKey, "Deleting object").Func(deleteObject)).
+		Display(printDeleteObject).
+		Run(),
+}
+
+func init() {
+	flags.RegisterConfirmation(objectDeleteCmd)
+	objectCmd.AddCommand(objectDeleteCmd)
+}
+
+func deleteObject(ctx *executor.Context, _ chan<- string) (bool, error) {
+	path := executor.Get(ctx, objectPathKey)
+	err := executor.Get(ctx, objectClientKey).DeleteObject(context.Background(), path.Bucket, path.Key)
+	return err == nil, err
+}
+
+func printDeleteObject(ctx *executor.Context) {
+	rb := response.New()
+	if ctx.Error != nil {
+		rb.Error("Error deleting object", ctx.Error).Display()
+		return
+	}
+	rb.FooterSuccessf("Successfully deleted %s %s", executor.Get(ctx, objectPathKey), ui.Muted(fmt.Sprintf("(took %v)", ctx.Duration))).Display()
+}
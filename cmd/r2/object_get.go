@@ -0,0 +1,106 @@
+package r2
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"dario.lol/cf/internal/executor"
+	"dario.lol/cf/internal/s3"
+	"dario.lol/cf/internal/ui"
+	"dario.lol/cf/internal/ui/response"
+	"github.com/spf13/cobra"
+)
+
+var objectDownloadKey = executor.NewKey[s3.ObjectInfo]("r2ObjectDownload")
+
+var objectGetCmd = &cobra.Command{
+	Use:   "get <bucket>/<key>",
+	Short: "Download an object",
+	Long:  "Download an object to a file, or stream it to stdout when no output file is given.",
+	Args:  cobra.ExactArgs(1),
+	Run: executor.New().
+		WithClient().
+		WithAccountID().
+		Step(executor.NewStep(objectClientKey, "Resolving R2 credentials").Func(newObjectClient).Silent()).
+		Step(executor.NewStep(objectPathKey, "Resolving object").Func(resolveObjectPath(true)).Silent()).
+		Step(executor.NewStep(objectDownloadKey, "Downloading object").Func(getObject)).
+		Display(printGetObject).
+		Run(),
+}
+
+func init() {
+	objectGetCmd.Flags().StringP("output", "o", "", "Write the object to a file instead of stdout")
+	objectCmd.AddCommand(objectGetCmd)
+}
+
+// progressInterval is how often transfers report their progress.
+const progressInterval = 100 * time.Millisecond
+
+type progressWriter struct {
+	w        io.Writer
+	written  int64
+	total    int64
+	progress chan<- string
+	label    string
+	reported time.Time
+}
+
+func (p *progressWriter) Write(b []byte) (int, error) {
+	n, err := p.w.Write(b)
+	p.written += int64(n)
+	if now := time.Now(); now.Sub(p.reported) >= progressInterval {
+		p.reported = now
+		executor.TryProgress(p.progress, fmt.Sprintf("%s (%s / %s)", p.label, ui.FormatBytes(p.written), ui.FormatBytes(p.total)))
+	}
+	return n, err
+}
+
+func getObject(ctx *executor.Context, progress chan<- string) (s3.ObjectInfo, error) {
+	path := executor.Get(ctx, objectPathKey)
+	client := executor.Get(ctx, objectClientKey)
+
+	body, info, err := client.GetObject(context.Background(), path.Bucket, path.Key)
+	if err != nil {
+		return s3.ObjectInfo{}, fmt.Errorf("error downloading %s: %w", path, err)
+	}
+	defer body.Close()
+
+	output, _ := ctx.Cmd.Flags().GetString("output")
+	if output == "" {
+		if _, err := io.Copy(os.Stdout, body); err != nil {
+			return s3.ObjectInfo{}, fmt.Errorf("error downloading %s: %w", path, err)
+		}
+		return info, nil
+	}
+
+	file, err := os.Create(output)
+	if err != nil {
+		return s3.ObjectInfo{}, fmt.Errorf("error creating output file: %w", err)
+	}
+	writer := &progressWriter{w: file, total: info.Size, progress: progress, label: "Downloading " + path.String()}
+	if _, err := io.Copy(writer, body); err != nil {
+		file.Close()
+		os.Remove(output)
+		return s3.ObjectInfo{}, fmt.Errorf("error downloading %s: %w", path, err)
+	}
+	return info, file.Close()
+}
+
+func printGetObject(ctx *executor.Context) {
+	if ctx.Error != nil {
+		response.New().Error("Error downloading object", ctx.Error).Display()
+		return
+	}
+	info := executor.Get(ctx, objectDownloadKey)
+	path := executor.Get(ctx, objectPathKey)
+	message := fmt.Sprintf("Downloaded %s (%s) %s", path, ui.FormatBytes(info.Size), ui.Muted(fmt.Sprintf("(took %v)", ctx.Duration)))
+
+	if output, _ := ctx.Cmd.Flags().GetString("output"); output == "" {
+		fmt.Fprintln(os.Stderr, ui.Success(message))
+		return
+	}
+	response.New().FooterSuccess(message).Display()
+}
@@ -0,0 +1,76 @@
+package r2
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"dario.lol/cf/internal/executor"
+	"dario.lol/cf/internal/s3"
+	"dario.lol/cf/internal/ui"
+	"dario.lol/cf/internal/ui/response"
+	"github.com/spf13/cobra"
+)
+
+var objectInfoKey = executor.NewKey[s3.ObjectInfo]("r2ObjectInfo")
+
+var objectHeadCmd = &cobra.Command{
+	Use:   "head <bucket>/<key>",
+	Short: "Show an object's metadata",
+	Args:  cobra.ExactArgs(1),
+	Run: executor.New().
+		WithClient().
+		WithAccountID().
+		Step(executor.NewStep(objectClientKey, "Resolving R2 credentials").Func(newObjectClient).Silent()).
+		Step(executor.NewStep(objectPathKey, "Resolving object").Func(resolveObjectPath(true)).Silent()).
+		Step(executor.NewStep(objectInfoKey, "Fetching object metadata").Func(headObject)).
+		Display(printHeadObject).
+		Run(),
+}
+
+func init() {
+	objectCmd.AddCommand(objectHeadCmd)
+}
+
+func headObject(ctx *executor.Context, _ chan<- string) (s3.ObjectInfo, error) {
+	path := executor.Get(ctx, objectPathKey)
+	info, err := executor.Get(ctx, objectClientKey).HeadObject(context.Background(), path.Bucket, path.Key)
+	if err != nil {
+		if s3.IsNotFound(err) {
+			return s3.ObjectInfo{}, fmt.Errorf("object %s not found", path)
+		}
+		return s3.ObjectInfo{}, err
+	}
+	return info, nil
+}
+
+func printHeadObject(ctx *executor.Context) {
+	rb := response.New()
+	if ctx.Error != nil {
+		rb.Error("Error fetching object metadata", ctx.Error).Display()
+		return
+	}
+	info := executor.Get(ctx, objectInfoKey)
+	path := executor.Get(ctx, objectPathKey)
+
+	icb := response.NewItemContent().
+		Add("Size:", ui.Text(fmt.Sprintf("%s (%d bytes)", ui.FormatBytes(info.Size), info.Size))).
+		Add("Content Type:", ui.Text(info.ContentType)).
+		Add("ETag:", ui.Text(info.ETag)).
+		Add("Modified:", ui.Text(info.LastModified.Local().Format("2006-01-02 15:04:05")))
+	if info.StorageClass != "" {
+		icb.Add("Storage Class:", ui.Text(info.StorageClass))
+	}
+	names := make([]string, 0, len(info.Metadata))
+	for name := range info.Metadata {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		icb.Add(name+":", ui.Text(info.Metadata[name]))
+	}
+
+	rb.AddItem(path.String(), icb.String()).
+		FooterSuccessf("Fetched object metadata %s", ui.Muted(fmt.Sprintf("(took %v)", ctx.Duration))).
+		Display()
+}
@@ -0,0 +1,109 @@
+package r2
+
+import (
+	"context"
+	"fmt"
+
+	"dario.lol/cf/internal/executor"
+	"dario.lol/cf/internal/s3"
+	"dario.lol/cf/internal/ui"
+	"dario.lol/cf/internal/ui/response"
+	"github.com/spf13/cobra"
+)
+
+var objectListingKey = executor.NewKey[*s3.ListResult]("r2ObjectListing")
+
+var objectListCmd = &cobra.Command{
+	Use:   "list <bucket>[/prefix]",
+	Short: "List objects in a bucket",
+	Long:  "List objects in a bucket. Use --delimiter / to list one level of a prefix like a directory.",
+	Args:  cobra.ExactArgs(1),
+	Run: executor.New().
+		WithClient().
+		WithAccountID().
+		Step(executor.NewStep(objectClientKey, "Resolving R2 credentials").Func(newObjectClient).Silent()).
+		Step(executor.NewStep(objectPathKey, "Resolving bucket").Func(resolveObjectPath(false)).Silent()).
+		Step(executor.NewStep(objectListingKey, "Listing objects").Func(listObjects)).
+		Display(printListObjects).
+		Run(),
+}
+
+func init() {
+	objectListCmd.Flags().String("prefix", "", "Only list objects starting with this prefix")
+	objectListCmd.Flags().String("delimiter", "", "Group keys sharing a prefix up to this delimiter")
+	objectListCmd.Flags().IntP("limit", "l", 0, "Maximum number of objects to list (0 = all)")
+	objectListCmd.Flags().String("cursor", "", "Continue listing from a cursor returned by a previous call")
+	objectListCmd.Flags().Bool("keys-only", false, "Print only object keys, one per line")
+	objectCmd.AddCommand(objectListCmd)
+}
+
+func listObjects(ctx *executor.Context, progress chan<- string) (*s3.ListResult, error) {
+	path := executor.Get(ctx, objectPathKey)
+	prefix, _ := ctx.Cmd.Flags().GetString("prefix")
+	delimiter, _ := ctx.Cmd.Flags().GetString("delimiter")
+	limit, _ := ctx.Cmd.Flags().GetInt("limit")
+	cursor, _ := ctx.Cmd.Flags().GetString("cursor")
+
+	opts := s3.ListOptions{
+		Prefix:            path.Key + prefix,
+		Delimiter:         delimiter,
+		ContinuationToken: cursor,
+	}
+	result, err := executor.Get(ctx, objectClientKey).ListAllObjects(context.Background(), path.Bucket, opts, limit, func(page *s3.ListResult) {
+		progress <- fmt.Sprintf("Listing objects (%d found)", len(page.Objects))
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error listing objects in %s: %w", path.Bucket, err)
+	}
+	return result, nil
+}
+
+func printListObjects(ctx *executor.Context) {
+	rb := response.New().Title("R2 Objects")
+
+	if ctx.Error != nil {
+		rb.Error("Error listing objects", ctx.Error).Display()
+		return
+	}
+
+	listing := executor.Get(ctx, objectListingKey)
+
+	if keysOnly, _ := ctx.Cmd.Flags().GetBool("keys-only"); keysOnly {
+		for _, prefix := range listing.CommonPrefixes {
+			fmt.Println(prefix)
+		}
+		for _, obj := range listing.Objects {
+			fmt.Println(obj.Key)
+		}
+		return
+	}
+
+	for _, prefix := range listing.CommonPrefixes {
+		rb.AddItem(prefix, ui.Muted("Prefix"))
+	}
+	var total int64
+	for _, obj := range listing.Objects {
+		total += obj.Size
+		rb.AddItem(obj.Key, response.NewItemContent().
+			Add("Size:", ui.Text(ui.FormatBytes(obj.Size))).
+			Add("Modified:", ui.Text(obj.LastModified.Local().Format("2006-01-02 15:04:05"))).
+			Add("ETag:", ui.Muted(obj.ETag)).
+			String())
+	}
+
+	if len(listing.Objects) == 0 && len(listing.CommonPrefixes) == 0 {
+		rb.NoItemsMessage("No objects found")
+	} else {
+		footer := fmt.Sprintf("Showing %d object(s), %s", len(listing.Objects), ui.FormatBytes(total))
+		if len(listing.CommonPrefixes) > 0 {
+			footer += fmt.Sprintf(" and %d prefix(es)", len(listing.CommonPrefixes))
+		}
+		if listing.IsTruncated {
+			footer += " " + ui.Muted(fmt.Sprintf("(more available, continue with --cursor %s)", listing.NextContinuationToken))
+		}
+		footer += " " + ui.Muted(fmt.Sprintf("(took %v)", ctx.Duration))
+		rb.FooterSuccess(footer)
+	}
+
+	rb.Display()
+}
@@ -0,0 +1,171 @@
+package r2
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"dario.lol/cf/internal/cloudflare"
+	"dario.lol/cf/internal/executor"
+	"dario.lol/cf/internal/s3"
+	"dario.lol/cf/internal/ui"
+	"dario.lol/cf/internal/ui/response"
+	"github.com/spf13/cobra"
+)
+
+type ObjectUpload struct {
+	Path   ObjectPath
+	Size   int64
+	Result *s3.UploadResult
+}
+
+var objectUploadKey = executor.NewKey[*ObjectUpload]("r2ObjectUpload")
+
+var objectPutCmd = &cobra.Command{
+	Use:   "put <bucket>/<key> [file]",
+	Short: "Upload an object",
+	Long:  "Upload a file, or stdin when no file is given, to an R2 bucket. Files larger than the part size are uploaded as a multipart upload with parts sent in parallel; an interrupted upload of an unchanged file is resumed on the next attempt within seven days, while a failed upload from stdin is aborted. When the key is omitted, the file name is used.",
+	Args:  cobra.RangeArgs(1, 2),
+	Run: executor.New().
+		WithClient().
+		WithAccountID().
+		Step(executor.NewStep(objectClientKey, "Resolving R2 credentials").Func(newObjectClient).Silent()).
+		Step(executor.NewStep(objectPathKey, "Resolving object").Func(resolveObjectPath(false)).Silent()).
+		Step(executor.NewStep(objectUploadKey, "Uploading object").Func(putObject)).
+		Display(printPutObject).
+		Run(),
+}
+
+func init() {
+	objectPutCmd.Flags().String("content-type", "", "The content type of the object (detected from the file when omitted)")
+	objectPutCmd.Flags().Int64("part-size", s3.DefaultPartSize>>20, "Multipart part size in MiB")
+	objectPutCmd.Flags().Int("concurrency", s3.DefaultConcurrency, "Number of parts to upload in parallel")
+	objectCmd.AddCommand(objectPutCmd)
+}
+
+func putObject(ctx *executor.Context, progress chan<- string) (*ObjectUpload, error) {
+	path := executor.Get(ctx, objectPathKey)
+	client := executor.Get(ctx, objectClientKey)
+
+	source := "-"
+	if len(ctx.Args) > 1 {
+		source = ctx.Args[1]
+	}
+	if path.Key == "" {
+		if source == "-" {
+			return nil, fmt.Errorf("an object key is required when uploading from stdin")
+		}
+		path.Key = filepath.Base(source)
+	}
+
+	file, err := openUploadSource(source)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	stat, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	size := stat.Size()
+
+	contentType, _ := ctx.Cmd.Flags().GetString("content-type")
+	if contentType == "" {
+		contentType, err = detectContentType(file, source)
+		if err != nil {
+			return nil, err
+		}
+	}
+	partSize, _ := ctx.Cmd.Flags().GetInt64("part-size")
+	concurrency, _ := ctx.Cmd.Flags().GetInt("concurrency")
+
+	opts := s3.UploadOptions{
+		PutOptions:  s3.PutOptions{ContentType: contentType},
+		PartSize:    partSize << 20,
+		Concurrency: concurrency,
+		Progress: func(uploaded, total int64) {
+			executor.TryProgress(progress, fmt.Sprintf("Uploading %s (%s / %s)", path, ui.FormatBytes(uploaded), ui.FormatBytes(total)))
+		},
+	}
+	// Stdin is spooled to a new temporary file each run, so an upload from
+	// it can never be resumed and is aborted on failure instead.
+	if source != "-" {
+		opts.Resume = cloudflare.R2ResumeStore
+		opts.Fingerprint = uploadFingerprint(file.Name(), stat)
+	}
+	result, err := client.Upload(context.Background(), path.Bucket, path.Key, file, size, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error uploading %s: %w", path, err)
+	}
+	return &ObjectUpload{Path: path, Size: size, Result: result}, nil
+}
+
+// uploadFingerprint identifies a local file's contents for resuming uploads.
+func uploadFingerprint(path string, info os.FileInfo) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	return fmt.Sprintf("%s:%d:%d", path, info.Size(), info.ModTime().UnixNano())
+}
+
+// openUploadSource opens the file to upload. Stdin is spooled to a temporary
+// file first because multipart uploads need random access to the data.
+func openUploadSource(source string) (*os.File, error) {
+	if source != "-" {
+		file, err := os.Open(source)
+		if err != nil {
+			return nil, fmt.Errorf("error opening file: %w", err)
+		}
+		return file, nil
+	}
+
+	tmp, err := os.CreateTemp("", "cf-r2-upload-*")
+	if err != nil {
+		return nil, err
+	}
+	// The file stays readable through the open handle after it is unlinked.
+	_ = os.Remove(tmp.Name())
+	if _, err := io.Copy(tmp, os.Stdin); err != nil {
+		tmp.Close()
+		return nil, fmt.Errorf("error reading stdin: %w", err)
+	}
+	return tmp, nil
+}
+
+func detectContentType(file io.ReaderAt, name string) (string, error) {
+	if name != "-" {
+		if contentType := mime.TypeByExtension(filepath.Ext(name)); contentType != "" {
+			return contentType, nil
+		}
+	}
+	head := make([]byte, 512)
+	n, err := file.ReadAt(head, 0)
+	if err != nil && err != io.EOF {
+		return "", err
+	}
+	return http.DetectContentType(head[:n]), nil
+}
+
+func printPutObject(ctx *executor.Context) {
+	rb := response.New()
+	if ctx.Error != nil {
+		rb.Error("Error uploading object", ctx.Error).Display()
+		return
+	}
+	upload := executor.Get(ctx, objectUploadKey)
+	footer := fmt.Sprintf("Uploaded %s (%s)", upload.Path, ui.FormatBytes(upload.Size))
+	if upload.Result.Multipart {
+		detail := fmt.Sprintf("%d parts", upload.Result.Parts)
+		if upload.Result.ResumedFrom > 0 {
+			detail += fmt.Sprintf(", %d resumed", upload.Result.ResumedFrom)
+		}
+		footer += " " + ui.Muted("("+detail+")")
+	}
+	footer += " " + ui.Muted(fmt.Sprintf("(took %v)", ctx.Duration))
+	rb.FooterSuccess(footer).Display()
+}
@@ -1,7 +1,10 @@
 package r2
 
 import (
+	"dario.lol/cf/internal/cloudflare"
+	"dario.lol/cf/internal/executor"
 	"dario.lol/cf/internal/flags"
+	"github.com/cloudflare/cloudflare-go/v6/option"
 	"github.com/spf13/cobra"
 )
 
@@ -17,5 +20,22 @@ var bucketCmd = &cobra.Command{
 
 func init() {
 	flags.RegisterAccountID(R2Cmd)
+	R2Cmd.PersistentFlags().String("jurisdiction", "", "The jurisdiction of the bucket (eu, fedramp)")
 	R2Cmd.AddCommand(bucketCmd)
 }
+
+// jurisdictionOption sends the --jurisdiction flag as the cf-r2-jurisdiction
+// header, which every bucket-scoped R2 endpoint accepts.
+func jurisdictionOption(ctx *executor.Context) []option.RequestOption {
+	jurisdiction, _ := ctx.Cmd.Flags().GetString("jurisdiction")
+	if jurisdiction == "" {
+		return nil
+	}
+	return []option.RequestOption{option.WithHeader("cf-r2-jurisdiction", jurisdiction)}
+}
+
+// bucketCacheTag is the cache tag of the bucket named by the first argument.
+func bucketCacheTag(ctx *executor.Context) string {
+	jurisdiction, _ := ctx.Cmd.Flags().GetString("jurisdiction")
+	return cloudflare.R2BucketCacheTag(ctx.AccountID, jurisdiction, ctx.Args[0])
+}
@@ -0,0 +1,511 @@
+package r2
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"dario.lol/cf/internal/cloudflare"
+	"dario.lol/cf/internal/executor"
+	"dario.lol/cf/internal/s3"
+	"dario.lol/cf/internal/ui"
+	"dario.lol/cf/internal/ui/response"
+	"github.com/alitto/pond/v2"
+	"github.com/spf13/cobra"
+)
+
+type syncOp string
+
+const (
+	syncUpload   syncOp = "upload"
+	syncDownload syncOp = "download"
+	syncDelete   syncOp = "delete"
+)
+
+type SyncAction struct {
+	Op     syncOp
+	Path   string
+	Size   int64
+	Reason string
+}
+
+type SyncPlan struct {
+	Upload    bool
+	LocalDir  string
+	Remote    ObjectPath
+	Actions   []SyncAction
+	Unchanged int
+}
+
+type SyncResult struct {
+	Uploaded   int
+	Downloaded int
+	Deleted    int
+	Bytes      int64
+	Failed     []string
+}
+
+type syncFile struct {
+	size    int64
+	modTime time.Time
+	etag    string
+}
+
+var (
+	syncPlanKey   = executor.NewKey[*SyncPlan]("r2SyncPlan")
+	syncResultKey = executor.NewKey[*SyncResult]("r2SyncResult")
+)
+
+var syncCmd = &cobra.Command{
+	Use:   "sync <source> <destination>",
+	Short: "Sync a local directory with an R2 bucket prefix",
+	Long: `Sync a local directory to an R2 bucket prefix or the other way round. One side must be an r2://bucket/prefix path.
+
+Files are transferred when their size differs, when the object's ETag does not match the file's MD5, or, for multipart objects, when the source is newer than the destination. Use --delete to remove destination files that no longer exist in the source.`,
+	Example: `  cf r2 sync ./dist r2://releases/v1.2.0
+  cf r2 sync r2://releases/v1.2.0 ./dist --exclude "*.map"
+  cf r2 sync ./dist r2://releases/latest --delete --dry-run`,
+	Args: cobra.ExactArgs(2),
+	Run: executor.New().
+		WithClient().
+		WithAccountID().
+		Step(executor.NewStep(objectClientKey, "Resolving R2 credentials").Func(newObjectClient).Silent()).
+		Step(executor.NewStep(syncPlanKey, "Comparing files").Func(planSync)).
+		Step(executor.NewStep(syncResultKey, "Syncing files").Func(runSync)).
+		Display(printSync).
+		Run(),
+}
+
+func init() {
+	syncCmd.Flags().Bool("delete", false, "Delete destination files that do not exist in the source")
+	syncCmd.Flags().Bool("dry-run", false, "Show what would be transferred without changing anything")
+	syncCmd.Flags().StringSlice("include", nil, "Only sync paths matching these globs (supports **)")
+	syncCmd.Flags().StringSlice("exclude", nil, "Skip paths matching these globs (supports **)")
+	syncCmd.Flags().Int("concurrency", 8, "Number of files to transfer in parallel")
+	R2Cmd.AddCommand(syncCmd)
+}
+
+func planSync(ctx *executor.Context, progress chan<- string) (*SyncPlan, error) {
+	source, destination := ctx.Args[0], ctx.Args[1]
+	plan := &SyncPlan{}
+	switch {
+	case strings.HasPrefix(source, "r2://") && !strings.HasPrefix(destination, "r2://"):
+		plan.LocalDir = destination
+		source = strings.TrimPrefix(source, "r2://")
+	case strings.HasPrefix(destination, "r2://") && !strings.HasPrefix(source, "r2://"):
+		plan.Upload = true
+		plan.LocalDir = source
+		source = strings.TrimPrefix(destination, "r2://")
+	default:
+		return nil, fmt.Errorf("exactly one of source and destination must be an r2://bucket/prefix path")
+	}
+
+	remote, err := parseObjectPath(source)
+	if err != nil {
+		return nil, err
+	}
+	if remote.Key != "" && !strings.HasSuffix(remote.Key, "/") {
+		remote.Key += "/"
+	}
+	plan.Remote = remote
+
+	filter, err := newSyncFilter(ctx.Cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	local, err := scanLocal(plan.LocalDir, filter, !plan.Upload)
+	if err != nil {
+		return nil, err
+	}
+
+	progress <- "Listing remote objects"
+	client := executor.Get(ctx, objectClientKey)
+	listing, err := client.ListAllObjects(context.Background(), remote.Bucket, s3.ListOptions{Prefix: remote.Key}, 0, func(page *s3.ListResult) {
+		progress <- fmt.Sprintf("Listing remote objects (%d found)", len(page.Objects))
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error listing %s: %w", remote, err)
+	}
+	remoteFiles := make(map[string]syncFile, len(listing.Objects))
+	for _, obj := range listing.Objects {
+		rel := strings.TrimPrefix(obj.Key, remote.Key)
+		if rel == "" || strings.HasSuffix(rel, "/") || !filter.match(rel) {
+			continue
+		}
+		remoteFiles[rel] = syncFile{size: obj.Size, modTime: obj.LastModified, etag: obj.ETag}
+	}
+
+	src, dst := local, remoteFiles
+	op := syncUpload
+	if !plan.Upload {
+		src, dst = remoteFiles, local
+		op = syncDownload
+	}
+
+	checked := 0
+	for rel, s := range src {
+		checked++
+		if checked%100 == 0 {
+			progress <- fmt.Sprintf("Comparing files (%d/%d)", checked, len(src))
+		}
+		d, exists := dst[rel]
+		reason := ""
+		switch {
+		case !exists:
+			reason = "new"
+		case s.size != d.size:
+			reason = "size changed"
+		default:
+			changed, why, err := contentChanged(filepath.Join(plan.LocalDir, filepath.FromSlash(rel)), s, d, plan.Upload)
+			if err != nil {
+				return nil, err
+			}
+			if changed {
+				reason = why
+			}
+		}
+		if reason == "" {
+			plan.Unchanged++
+			continue
+		}
+		plan.Actions = append(plan.Actions, SyncAction{Op: op, Path: rel, Size: s.size, Reason: reason})
+	}
+
+	if deleteExtra, _ := ctx.Cmd.Flags().GetBool("delete"); deleteExtra {
+		for rel, d := range dst {
+			if _, ok := src[rel]; !ok {
+				plan.Actions = append(plan.Actions, SyncAction{Op: syncDelete, Path: rel, Size: d.size, Reason: "not in source"})
+			}
+		}
+	}
+
+	sort.Slice(plan.Actions, func(i, j int) bool { return plan.Actions[i].Path < plan.Actions[j].Path })
+	return plan, nil
+}
+
+// contentChanged compares files of equal size. Single-part ETags are the
+// object's MD5; multipart ETags are not, so those fall back to mtime.
+func contentChanged(localPath string, src, dst syncFile, upload bool) (bool, string, error) {
+	etag := dst.etag
+	if !upload {
+		etag = src.etag
+	}
+	if len(etag) == 32 && !strings.Contains(etag, "-") {
+		sum, err := fileMD5(localPath)
+		if err != nil {
+			return false, "", err
+		}
+		return sum != etag, "checksum changed", nil
+	}
+	return src.modTime.After(dst.modTime), "newer", nil
+}
+
+func fileMD5(name string) (string, error) {
+	file, err := os.Open(name)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	h := md5.New()
+	if _, err := io.Copy(h, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func scanLocal(dir string, filter *syncFilter, allowMissing bool) (map[string]syncFile, error) {
+	files := make(map[string]syncFile)
+	if _, err := os.Stat(dir); os.IsNotExist(err) && allowMissing {
+		return files, nil
+	}
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if !filter.match(rel) {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		files[rel] = syncFile{size: info.Size(), modTime: info.ModTime()}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error scanning %s: %w", dir, err)
+	}
+	return files, nil
+}
+
+func runSync(ctx *executor.Context, progress chan<- string) (*SyncResult, error) {
+	plan := executor.Get(ctx, syncPlanKey)
+	result := &SyncResult{}
+	if dryRun, _ := ctx.Cmd.Flags().GetBool("dry-run"); dryRun || len(plan.Actions) == 0 {
+		return result, nil
+	}
+
+	client := executor.Get(ctx, objectClientKey)
+	concurrency, _ := ctx.Cmd.Flags().GetInt("concurrency")
+
+	var mu sync.Mutex
+	var done atomic.Int32
+	var bytes atomic.Int64
+	pool := pond.NewPool(max(concurrency, 1))
+	group := pool.NewGroup()
+	for _, action := range plan.Actions {
+		action := action
+		group.Submit(func() {
+			err := runSyncAction(client, plan, action)
+			if err == nil && action.Op != syncDelete {
+				bytes.Add(action.Size)
+			}
+			mu.Lock()
+			if err != nil {
+				result.Failed = append(result.Failed, fmt.Sprintf("%s %s: %v", action.Op, action.Path, err))
+			} else {
+				switch action.Op {
+				case syncUpload:
+					result.Uploaded++
+				case syncDownload:
+					result.Downloaded++
+				case syncDelete:
+					result.Deleted++
+				}
+				if action.Op != syncDelete {
+					result.Bytes += action.Size
+				}
+			}
+			mu.Unlock()
+			executor.TryProgress(progress, fmt.Sprintf("Syncing files (%d/%d, %s)", done.Add(1), len(plan.Actions), ui.FormatBytes(bytes.Load())))
+		})
+	}
+	group.Wait()
+	pool.StopAndWait()
+
+	sort.Strings(result.Failed)
+	return result, nil
+}
+
+func runSyncAction(client *s3.Client, plan *SyncPlan, action SyncAction) error {
+	localPath := filepath.Join(plan.LocalDir, filepath.FromSlash(action.Path))
+	key := plan.Remote.Key + action.Path
+
+	switch action.Op {
+	case syncUpload:
+		file, err := os.Open(localPath)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		contentType, err := detectContentType(file, localPath)
+		if err != nil {
+			return err
+		}
+		info, err := file.Stat()
+		if err != nil {
+			return err
+		}
+		_, err = client.Upload(context.Background(), plan.Remote.Bucket, key, file, action.Size, s3.UploadOptions{
+			PutOptions:  s3.PutOptions{ContentType: contentType},
+			Resume:      cloudflare.R2ResumeStore,
+			Fingerprint: uploadFingerprint(localPath, info),
+		})
+		return err
+	case syncDownload:
+		return downloadTo(client, plan.Remote.Bucket, key, localPath)
+	case syncDelete:
+		if plan.Upload {
+			return client.DeleteObject(context.Background(), plan.Remote.Bucket, key)
+		}
+		return os.Remove(localPath)
+	}
+	return nil
+}
+
+// downloadTo writes the object to a temporary file next to localPath and
+// renames it into place, then sets its mtime to the object's so later syncs
+// see it as unchanged.
+func downloadTo(client *s3.Client, bucket, key, localPath string) error {
+	body, info, err := client.GetObject(context.Background(), bucket, key)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	if err := os.MkdirAll(filepath.Dir(localPath), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(localPath), ".cf-sync-*")
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(tmp, body); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Chmod(0o644); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), localPath); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if !info.LastModified.IsZero() {
+		_ = os.Chtimes(localPath, info.LastModified, info.LastModified)
+	}
+	return nil
+}
+
+type syncFilter struct {
+	include []*regexp.Regexp
+	exclude []*regexp.Regexp
+}
+
+func newSyncFilter(cmd *cobra.Command) (*syncFilter, error) {
+	include, _ := cmd.Flags().GetStringSlice("include")
+	exclude, _ := cmd.Flags().GetStringSlice("exclude")
+	filter := &syncFilter{}
+	for _, pattern := range include {
+		re, err := globToRegexp(pattern)
+		if err != nil {
+			return nil, err
+		}
+		filter.include = append(filter.include, re)
+	}
+	for _, pattern := range exclude {
+		re, err := globToRegexp(pattern)
+		if err != nil {
+			return nil, err
+		}
+		filter.exclude = append(filter.exclude, re)
+	}
+	return filter, nil
+}
+
+// match reports whether the slash-separated relative path passes the
+// filters. Patterns without a slash are matched against the base name.
+func (f *syncFilter) match(rel string) bool {
+	matches := func(patterns []*regexp.Regexp) bool {
+		for _, re := range patterns {
+			if re.MatchString(rel) || re.MatchString(path.Base(rel)) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(f.include) > 0 && !matches(f.include) {
+		return false
+	}
+	return !matches(f.exclude)
+}
+
+// globToRegexp converts a glob to an anchored regexp. "*" and "?" do not
+// cross "/", "**" does.
+func globToRegexp(pattern string) (*regexp.Regexp, error) {
+	var b strings.Builder
+	b.WriteString("^")
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		switch c {
+		case '*':
+			if i+1 < len(pattern) && pattern[i+1] == '*' {
+				i++
+				if i+1 < len(pattern) && pattern[i+1] == '/' {
+					i++
+					b.WriteString("(.*/)?")
+				} else {
+					b.WriteString(".*")
+				}
+			} else {
+				b.WriteString("[^/]*")
+			}
+		case '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	b.WriteString("$")
+	re, err := regexp.Compile(b.String())
+	if err != nil {
+		return nil, fmt.Errorf("invalid glob %q: %w", pattern, err)
+	}
+	return re, nil
+}
+
+func printSync(ctx *executor.Context) {
+	rb := response.New().Title("R2 Sync")
+	if ctx.Error != nil {
+		rb.Error("Error syncing files", ctx.Error).Display()
+		return
+	}
+
+	plan := executor.Get(ctx, syncPlanKey)
+	result := executor.Get(ctx, syncResultKey)
+	target := "r2://" + plan.Remote.String()
+
+	if dryRun, _ := ctx.Cmd.Flags().GetBool("dry-run"); dryRun {
+		for _, action := range plan.Actions {
+			rb.AddItem(action.Path, response.NewItemContent().
+				Add("Action:", ui.Text(string(action.Op))).
+				Add("Size:", ui.Text(ui.FormatBytes(action.Size))).
+				Add("Reason:", ui.Muted(action.Reason)).
+				String())
+		}
+		if len(plan.Actions) == 0 {
+			rb.NoItemsMessage("Everything is up to date")
+		}
+		rb.FooterSuccessf("Dry run: %d change(s), %d file(s) unchanged between %s and %s %s", len(plan.Actions), plan.Unchanged, plan.LocalDir, target, ui.Muted(fmt.Sprintf("(took %v)", ctx.Duration))).Display()
+		return
+	}
+
+	if len(result.Failed) > 0 {
+		rb.AddItem("Failed", ui.BulletList(result.Failed))
+	}
+	rb.Summary("Uploaded", result.Uploaded).
+		Summary("Downloaded", result.Downloaded).
+		Summary("Deleted", result.Deleted).
+		Summary("Unchanged", plan.Unchanged).
+		Summary("Transferred", ui.FormatBytes(result.Bytes))
+
+	var footer string
+	if plan.Upload {
+		footer = fmt.Sprintf("Synced %s to %s", plan.LocalDir, target)
+	} else {
+		footer = fmt.Sprintf("Synced %s to %s", target, plan.LocalDir)
+	}
+	if len(result.Failed) > 0 {
+		footer += " " + ui.Warning(fmt.Sprintf("(%d failed)", len(result.Failed)))
+	}
+	footer += " " + ui.Muted(fmt.Sprintf("(took %v)", ctx.Duration))
+	rb.FooterSuccess(footer).Display()
+}
@@ -0,0 +1,125 @@
+package r2
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"dario.lol/cf/internal/cloudflare"
+	"dario.lol/cf/internal/executor"
+	cf "github.com/cloudflare/cloudflare-go/v6"
+	"github.com/cloudflare/cloudflare-go/v6/accounts"
+	"github.com/cloudflare/cloudflare-go/v6/shared"
+	"github.com/spf13/cobra"
+)
+
+const (
+	bucketResourcePrefix  = "com.cloudflare.edge.r2.bucket."
+	accountResourcePrefix = "com.cloudflare.api.account."
+)
+
+// r2PermissionGroups maps a --permission value to the account permission
+// groups it grants. Object permissions are scoped to buckets, admin to the
+// whole account.
+var r2PermissionGroups = map[string][]string{
+	"read":  {"Workers R2 Storage Bucket Item Read"},
+	"write": {"Workers R2 Storage Bucket Item Read", "Workers R2 Storage Bucket Item Write"},
+	"admin": {"Workers R2 Storage Read", "Workers R2 Storage Write"},
+}
+
+var tokenCmd = &cobra.Command{
+	Use:   "token",
+	Short: "Manage account API tokens used as R2 S3 credentials",
+}
+
+func init() {
+	R2Cmd.AddCommand(tokenCmd)
+}
+
+// lookupPermissionGroups resolves permission group names to IDs, caching the
+// mapping since the IDs never change.
+func lookupPermissionGroups(ctx *executor.Context, names []string) ([]shared.TokenPolicyPermissionGroupParam, error) {
+	groups := make([]shared.TokenPolicyPermissionGroupParam, 0, len(names))
+	var missing []string
+	for _, name := range names {
+		if id, ok := cloudflare.GetID("permission_group:" + name); ok {
+			groups = append(groups, shared.TokenPolicyPermissionGroupParam{ID: cf.F(id)})
+		} else {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) == 0 {
+		return groups, nil
+	}
+
+	pager := ctx.Client.Accounts.Tokens.PermissionGroups.ListAutoPaging(context.Background(), accounts.TokenPermissionGroupListParams{
+		AccountID: cf.F(ctx.AccountID),
+	})
+	found := make(map[string]string)
+	for pager.Next() {
+		group := pager.Current()
+		found[group.Name] = group.ID
+		cloudflare.SetID("permission_group:"+group.Name, group.ID)
+	}
+	if err := pager.Err(); err != nil {
+		return nil, fmt.Errorf("error listing permission groups: %w", err)
+	}
+	for _, name := range missing {
+		id, ok := found[name]
+		if !ok {
+			return nil, fmt.Errorf("permission group %q not found", name)
+		}
+		groups = append(groups, shared.TokenPolicyPermissionGroupParam{ID: cf.F(id)})
+	}
+	return groups, nil
+}
+
+func isR2Token(token shared.Token) bool {
+	for _, policy := range token.Policies {
+		for _, group := range policy.PermissionGroups {
+			if strings.Contains(group.Name, "R2 Storage") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// tokenScope describes an R2 token's permission and the buckets it covers.
+func tokenScope(token shared.Token) (permission string, buckets []string) {
+	names := make(map[string]bool)
+	for _, policy := range token.Policies {
+		for _, group := range policy.PermissionGroups {
+			names[group.Name] = true
+		}
+		if resources, ok := policy.Resources.(shared.TokenPolicyResourcesIAMResourcesTypeObjectString); ok {
+			for resource := range resources {
+				if strings.HasPrefix(resource, bucketResourcePrefix) {
+					bucket := strings.TrimPrefix(resource, bucketResourcePrefix)
+					// Resource names are <account>_<jurisdiction>_<bucket>.
+					if parts := strings.SplitN(bucket, "_", 3); len(parts) == 3 {
+						bucket = parts[2]
+						if parts[1] != "default" {
+							bucket = parts[1] + "/" + bucket
+						}
+					}
+					buckets = append(buckets, bucket)
+				}
+			}
+		}
+	}
+
+	switch {
+	case names["Workers R2 Storage Write"]:
+		permission = "admin"
+	case names["Workers R2 Storage Read"]:
+		permission = "admin read"
+	case names["Workers R2 Storage Bucket Item Write"]:
+		permission = "write"
+	case names["Workers R2 Storage Bucket Item Read"]:
+		permission = "read"
+	default:
+		permission = "custom"
+	}
+	return permission, buckets
+}
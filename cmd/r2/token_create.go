@@ -0,0 +1,137 @@
+package r2
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"dario.lol/cf/internal/cloudflare"
+	"dario.lol/cf/internal/executor"
+	"dario.lol/cf/internal/ui"
+	"dario.lol/cf/internal/ui/response"
+	cf "github.com/cloudflare/cloudflare-go/v6"
+	"github.com/cloudflare/cloudflare-go/v6/accounts"
+	"github.com/cloudflare/cloudflare-go/v6/shared"
+	"github.com/spf13/cobra"
+)
+
+var createdTokenKey = executor.NewKey[*accounts.TokenNewResponse]("r2CreatedToken")
+
+var tokenCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create an API token with S3 credentials for R2",
+	Long: `Create an account API token scoped to R2 and print the S3 credentials derived from it: the token ID is the Access Key ID and the SHA-256 hash of the token value is the Secret Access Key.
+
+read and write tokens are limited to the buckets given with --bucket; admin tokens apply to every bucket in the account. The credentials are only shown once.`,
+	Example: `  cf r2 token create --bucket releases --permission write
+  cf r2 token create --bucket logs --bucket backups --permission read --ttl 720h
+  cf r2 token create --permission admin --name ci-admin`,
+	Args: cobra.NoArgs,
+	Run: executor.New().
+		WithClient().
+		WithAccountID().
+		Step(executor.NewStep(createdTokenKey, "Creating token").Func(createToken)).
+		Invalidates(func(ctx *executor.Context) []string {
+			return []string{"r2:tokens:"}
+		}).
+		Display(printCreateToken).
+		Run(),
+}
+
+func init() {
+	tokenCreateCmd.Flags().StringSlice("bucket", nil, "Bucket the token can access (repeatable)")
+	tokenCreateCmd.Flags().String("permission", "read", "Access level: read, write or admin")
+	tokenCreateCmd.Flags().Duration("ttl", 0, "Expire the token after this duration, e.g. 720h (default: never)")
+	tokenCreateCmd.Flags().String("name", "", "Token name (default: derived from buckets and permission)")
+	tokenCmd.AddCommand(tokenCreateCmd)
+}
+
+func createToken(ctx *executor.Context, _ chan<- string) (*accounts.TokenNewResponse, error) {
+	buckets, _ := ctx.Cmd.Flags().GetStringSlice("bucket")
+	permission, _ := ctx.Cmd.Flags().GetString("permission")
+	ttl, _ := ctx.Cmd.Flags().GetDuration("ttl")
+	name, _ := ctx.Cmd.Flags().GetString("name")
+	jurisdiction, _ := ctx.Cmd.Flags().GetString("jurisdiction")
+
+	permission = strings.ToLower(permission)
+	groupNames, ok := r2PermissionGroups[permission]
+	if !ok {
+		return nil, fmt.Errorf("invalid --permission %q, expected read, write or admin", permission)
+	}
+
+	resources := shared.TokenPolicyResourcesIAMResourcesTypeObjectStringParam{}
+	if permission == "admin" {
+		if len(buckets) > 0 {
+			return nil, fmt.Errorf("admin tokens apply to all buckets, drop --bucket or use --permission write")
+		}
+		resources[accountResourcePrefix+ctx.AccountID] = "*"
+	} else {
+		if len(buckets) == 0 {
+			return nil, fmt.Errorf("at least one --bucket is required for %s tokens", permission)
+		}
+		if jurisdiction == "" {
+			jurisdiction = "default"
+		}
+		for _, bucket := range buckets {
+			if _, err := cloudflare.LookupR2Bucket(ctx.Client, ctx.AccountID, bucket, jurisdiction); err != nil {
+				return nil, err
+			}
+			resources[fmt.Sprintf("%s%s_%s_%s", bucketResourcePrefix, ctx.AccountID, jurisdiction, bucket)] = "*"
+		}
+	}
+
+	groups, err := lookupPermissionGroups(ctx, groupNames)
+	if err != nil {
+		return nil, err
+	}
+
+	if name == "" {
+		scope := "all buckets"
+		if len(buckets) > 0 {
+			scope = strings.Join(buckets, ", ")
+		}
+		name = fmt.Sprintf("R2 %s: %s (cf)", permission, scope)
+	}
+
+	params := accounts.TokenNewParams{
+		AccountID: cf.F(ctx.AccountID),
+		Name:      cf.F(name),
+		Policies: cf.F([]shared.TokenPolicyParam{{
+			Effect:           cf.F(shared.TokenPolicyEffectAllow),
+			PermissionGroups: cf.F(groups),
+			Resources:        cf.F[shared.TokenPolicyResourcesUnionParam](resources),
+		}}),
+	}
+	if ttl > 0 {
+		params.ExpiresOn = cf.F(time.Now().Add(ttl).UTC().Truncate(time.Second))
+	}
+
+	return ctx.Client.Accounts.Tokens.New(context.Background(), params)
+}
+
+func printCreateToken(ctx *executor.Context) {
+	rb := response.New()
+	if ctx.Error != nil {
+		rb.Error("Error creating token", ctx.Error).Display()
+		return
+	}
+	token := executor.Get(ctx, createdTokenKey)
+	jurisdiction, _ := ctx.Cmd.Flags().GetString("jurisdiction")
+
+	sum := sha256.Sum256([]byte(token.Value))
+	icb := response.NewItemContent().
+		Add("Access Key ID:", ui.Text(token.ID)).
+		Add("Secret Access Key:", ui.Text(hex.EncodeToString(sum[:]))).
+		Add("Endpoint:", ui.Text(cloudflare.R2Endpoint(ctx.AccountID, jurisdiction))).
+		Add("Token Value:", ui.Text(token.Value))
+	if !token.ExpiresOn.IsZero() {
+		icb.Add("Expires:", ui.Text(token.ExpiresOn.Local().Format("2006-01-02 15:04:05")))
+	}
+
+	rb.AddItem(token.Name, icb.String()+"\n\n"+ui.Warning("Store these credentials now, they will not be shown again.")).
+		FooterSuccessf("Created token %s %s", token.ID, ui.Muted(fmt.Sprintf("(took %v)", ctx.Duration))).
+		Display()
+}
@@ -0,0 +1,113 @@
+package r2
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"dario.lol/cf/internal/executor"
+	"dario.lol/cf/internal/pagination"
+	"dario.lol/cf/internal/ui"
+	"dario.lol/cf/internal/ui/response"
+	cf "github.com/cloudflare/cloudflare-go/v6"
+	"github.com/cloudflare/cloudflare-go/v6/accounts"
+	"github.com/spf13/cobra"
+)
+
+type R2Token struct {
+	ID         string    `json:"id"`
+	Name       string    `json:"name"`
+	Status     string    `json:"status"`
+	Permission string    `json:"permission"`
+	Buckets    []string  `json:"buckets"`
+	ExpiresOn  time.Time `json:"expires_on"`
+	LastUsedOn time.Time `json:"last_used_on"`
+}
+
+var r2TokensKey = executor.NewKey[[]R2Token]("r2Tokens")
+
+var tokenListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List account API tokens with R2 permissions",
+	Args:  cobra.NoArgs,
+	Run: executor.New().
+		WithClient().
+		WithAccountID().
+		WithPagination().
+		Step(executor.NewStep(r2TokensKey, "Listing tokens").
+			Func(listTokens).
+			CacheKey("r2:tokens:list")).
+		Display(printListTokens).
+		Run(),
+}
+
+func init() {
+	pagination.RegisterFlags(tokenListCmd)
+	tokenCmd.AddCommand(tokenListCmd)
+}
+
+func listTokens(ctx *executor.Context, _ chan<- string) ([]R2Token, error) {
+	pager := ctx.Client.Accounts.Tokens.ListAutoPaging(context.Background(), accounts.TokenListParams{
+		AccountID: cf.F(ctx.AccountID),
+	})
+	var tokens []R2Token
+	for pager.Next() {
+		token := pager.Current()
+		if !isR2Token(token) {
+			continue
+		}
+		permission, buckets := tokenScope(token)
+		tokens = append(tokens, R2Token{
+			ID:         token.ID,
+			Name:       token.Name,
+			Status:     string(token.Status),
+			Permission: permission,
+			Buckets:    buckets,
+			ExpiresOn:  token.ExpiresOn,
+			LastUsedOn: token.LastUsedOn,
+		})
+	}
+	return tokens, pager.Err()
+}
+
+func printListTokens(ctx *executor.Context) {
+	rb := response.New().Title("R2 Tokens")
+
+	if ctx.Error != nil {
+		rb.Error("Error listing tokens", ctx.Error).Display()
+		return
+	}
+
+	tokens := executor.Get(ctx, r2TokensKey)
+	paginated, info := pagination.Paginate(tokens, ctx.Pagination)
+
+	for _, token := range paginated {
+		buckets := "All buckets"
+		if len(token.Buckets) > 0 {
+			buckets = strings.Join(token.Buckets, ", ")
+		}
+		icb := response.NewItemContent().
+			Add("Access Key ID:", ui.Text(token.ID)).
+			Add("Permission:", ui.Text(token.Permission)).
+			Add("Buckets:", ui.Text(buckets)).
+			Add("Status:", ui.Text(token.Status))
+		if !token.ExpiresOn.IsZero() {
+			icb.Add("Expires:", ui.Text(token.ExpiresOn.Local().Format("2006-01-02 15:04:05")))
+		}
+		if !token.LastUsedOn.IsZero() {
+			icb.Add("Last Used:", ui.Text(token.LastUsedOn.Local().Format("2006-01-02 15:04:05")))
+		}
+		rb.AddItem(token.Name, icb.String())
+	}
+
+	if len(paginated) == 0 {
+		rb.NoItemsMessage("No R2 tokens found")
+	} else {
+		footer := info.FooterMessage("token(s)")
+		footer += " " + ui.Muted(fmt.Sprintf("(took %v)", ctx.Duration))
+		rb.FooterSuccess(footer)
+	}
+
+	rb.Display()
+}
@@ -0,0 +1,59 @@
+package r2
+
+import (
+	"context"
+	"fmt"
+
+	"dario.lol/cf/internal/executor"
+	"dario.lol/cf/internal/flags"
+	"dario.lol/cf/internal/ui"
+	"dario.lol/cf/internal/ui/response"
+	cf "github.com/cloudflare/cloudflare-go/v6"
+	"github.com/cloudflare/cloudflare-go/v6/accounts"
+	"github.com/spf13/cobra"
+)
+
+var revokedTokenKey = executor.NewKey[string]("r2RevokedToken")
+
+var tokenRevokeCmd = &cobra.Command{
+	Use:   "revoke <access_key_id>",
+	Short: "Revoke an R2 token",
+	Long:  "Revoke an account API token by its ID, which is also its R2 Access Key ID. Clients using its credentials stop working immediately.",
+	Args:  cobra.ExactArgs(1),
+	Run: executor.New().
+		WithClient().
+		WithAccountID().
+		WithConfirmationFunc(func(ctx *executor.Context) string {
+			return fmt.Sprintf("Are you sure you want to revoke token %s?", ctx.Args[0])
+		}).
+		Step(executor.NewStep(revokedTokenKey, "Revoking token").Func(revokeToken)).
+		Invalidates(func(ctx *executor.Context) []string {
+			return []string{"r2:tokens:"}
+		}).
+		Display(printRevokeToken).
+		Run(),
+}
+
+func init() {
+	flags.RegisterConfirmation(tokenRevokeCmd)
+	tokenCmd.AddCommand(tokenRevokeCmd)
+}
+
+func revokeToken(ctx *executor.Context, _ chan<- string) (string, error) {
+	res, err := ctx.Client.Accounts.Tokens.Delete(context.Background(), ctx.Args[0], accounts.TokenDeleteParams{
+		AccountID: cf.F(ctx.AccountID),
+	})
+	if err != nil {
+		return "", err
+	}
+	return res.ID, nil
+}
+
+func printRevokeToken(ctx *executor.Context) {
+	rb := response.New()
+	if ctx.Error != nil {
+		rb.Error("Error revoking token", ctx.Error).Display()
+		return
+	}
+	rb.FooterSuccessf("Revoked token %s %s", executor.Get(ctx, revokedTokenKey), ui.Muted(fmt.Sprintf("(took %v)", ctx.Duration))).Display()
+}
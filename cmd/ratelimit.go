@@ -0,0 +1,9 @@
+package cmd
+
+import (
+	"dario.lol/cf/cmd/ratelimit"
+)
+
+func init() {
+	rootCmd.AddCommand(ratelimit.RateLimitCmd)
+}
@@ -0,0 +1,122 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"dario.lol/cf/internal/cloudflare"
+	"dario.lol/cf/internal/executor"
+	"dario.lol/cf/internal/ui"
+	"dario.lol/cf/internal/ui/response"
+	cf "github.com/cloudflare/cloudflare-go/v6"
+	"github.com/cloudflare/cloudflare-go/v6/zones"
+	"github.com/spf13/cobra"
+)
+
+var createCmd = &cobra.Command{
+	Use:   "create <zone>",
+	Short: "Create a rate limiting rule",
+	Long:  "Create a rate limiting rule. Which periods, timeouts, actions and characteristics are available depends on the zone's plan, and unsupported values are rejected before anything is changed.",
+	Example: `  cf rate-limit create example.com --expression 'http.request.uri.path eq "/login"' --requests 5 --period 10s --timeout 10s
+  cf rate-limit create example.com --expression 'starts_with(http.request.uri.path, "/api/")' --requests 100 --period 1m --action managed_challenge
+  cf rate-limit create example.com --expression 'true' --characteristics header:x-api-key --requests 1000 --period 1m`,
+	Args: cobra.ExactArgs(1),
+	Run: executor.New().
+		WithClient().
+		WithZone().
+		Step(executor.NewStep(ruleKey, "Creating rate limiting rule").Func(createRule)).
+		Invalidates(executor.PhaseTags(phase)).
+		Display(printCreateRule).
+		Run(),
+}
+
+func init() {
+	createCmd.Flags().String("expression", "", "Expression matching the requests to count")
+	createCmd.Flags().StringSlice("characteristics", []string{"ip"}, "What to count requests by: ip, header:<name>, cookie:<name>, query:<name> or a field name")
+	createCmd.Flags().Duration("period", 10*time.Second, "Period over which requests are counted")
+	createCmd.Flags().Int("requests", 0, "Number of requests allowed per period")
+	createCmd.Flags().Duration("timeout", 10*time.Second, "How long to keep blocking once the rate is exceeded (block action only)")
+	createCmd.Flags().String("action", "block", fmt.Sprintf("Action to take: %v", allActions))
+	createCmd.Flags().String("description", "", "Description of the rule")
+	_ = createCmd.MarkFlagRequired("expression")
+	_ = createCmd.MarkFlagRequired("requests")
+	RateLimitCmd.AddCommand(createCmd)
+}
+
+func createRule(ctx *executor.Context, progress chan<- string) (*cloudflare.Rule, error) {
+	expression, _ := ctx.Cmd.Flags().GetString("expression")
+	characteristics, _ := ctx.Cmd.Flags().GetStringSlice("characteristics")
+	period, _ := ctx.Cmd.Flags().GetDuration("period")
+	requests, _ := ctx.Cmd.Flags().GetInt("requests")
+	timeout, _ := ctx.Cmd.Flags().GetDuration("timeout")
+	action, _ := ctx.Cmd.Flags().GetString("action")
+	description, _ := ctx.Cmd.Flags().GetString("description")
+
+	if requests < 1 {
+		return nil, fmt.Errorf("--requests must be at least 1")
+	}
+	if action != "block" {
+		if ctx.Cmd.Flags().Changed("timeout") {
+			return nil, fmt.Errorf("--timeout only applies to the block action, %s applies for the rest of the period", action)
+		}
+		timeout = 0
+	}
+
+	var fields []string
+	for _, c := range characteristics {
+		field, err := characteristicField(c)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.Contains(fields, field) {
+			fields = append(fields, field)
+		}
+	}
+	if !slices.Contains(fields, "cf.colo.id") {
+		fields = append(fields, "cf.colo.id")
+	}
+
+	zone, err := ctx.Client.Zones.Get(context.Background(), zones.ZoneGetParams{
+		ZoneID: cf.F(executor.Get(ctx, executor.ZoneIDKey)),
+	})
+	if err != nil {
+		return nil, err
+	}
+	progress <- "Fetching rate limiting rules"
+	existing, err := phase.Rules(ctx.Client, executor.Get(ctx, executor.ZoneIDKey))
+	if err != nil {
+		return nil, err
+	}
+	if err := limitsFor(zone.Plan.LegacyID).validate(fields, int(period.Seconds()), int(timeout.Seconds()), action, len(existing.Rules)); err != nil {
+		return nil, err
+	}
+
+	rule := cloudflare.Rule{
+		Expression:  expression,
+		Action:      action,
+		Description: description,
+		Ratelimit: &cloudflare.Ratelimit{
+			Characteristics:   fields,
+			Period:            int(period.Seconds()),
+			RequestsPerPeriod: requests,
+			MitigationTimeout: int(timeout.Seconds()),
+		},
+	}
+	progress <- "Creating rate limiting rule"
+	_, created, err := phase.AddRule(ctx.Client, executor.Get(ctx, executor.ZoneIDKey), rule, cloudflare.RulePosition{})
+	return created, err
+}
+
+func printCreateRule(ctx *executor.Context) {
+	rb := response.New()
+	if ctx.Error != nil {
+		rb.Error("Error creating rate limiting rule", ctx.Error).Display()
+		return
+	}
+	rule := executor.Get(ctx, ruleKey)
+	rb.AddItem(rule.Title(), ruleContent(*rule)).
+		FooterSuccessf("Created rate limiting rule %s in %s %s", rule.ID, executor.Get(ctx, executor.ZoneNameKey), ui.Muted(fmt.Sprintf("(took %v)", ctx.Duration))).
+		Display()
+}
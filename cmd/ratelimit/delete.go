@@ -0,0 +1,47 @@
+package ratelimit
+
+import (
+	"fmt"
+
+	"dario.lol/cf/internal/cloudflare"
+	"dario.lol/cf/internal/executor"
+	"dario.lol/cf/internal/flags"
+	"dario.lol/cf/internal/ui"
+	"dario.lol/cf/internal/ui/response"
+	"github.com/spf13/cobra"
+)
+
+var deleteCmd = &cobra.Command{
+	Use:   "delete <zone> <rule>",
+	Short: "Delete a rate limiting rule by ID or description",
+	Args:  cobra.ExactArgs(2),
+	Run: executor.New().
+		WithClient().
+		WithZone().
+		Step(executor.NewStep(ruleKey, "Fetching rate limiting rules").Func(executor.PhaseRule(phase, rulesetKey))).
+		WithConfirmationFunc(func(ctx *executor.Context) string {
+			return fmt.Sprintf("Are you sure you want to delete rate limiting rule %s in zone %s?", executor.Get(ctx, ruleKey).Label(), executor.Get(ctx, executor.ZoneNameKey))
+		}).
+		Step(executor.NewStep(rulesetKey, "Deleting rate limiting rule").Func(deleteRule)).
+		Invalidates(executor.PhaseTags(phase)).
+		Display(printDeleteRule).
+		Run(),
+}
+
+func init() {
+	flags.RegisterConfirmation(deleteCmd)
+	RateLimitCmd.AddCommand(deleteCmd)
+}
+
+func deleteRule(ctx *executor.Context, _ chan<- string) (*cloudflare.Ruleset, error) {
+	return cloudflare.DeleteRule(ctx.Client, cloudflare.ZoneScope(executor.Get(ctx, executor.ZoneIDKey)), executor.Get(ctx, rulesetKey).ID, executor.Get(ctx, ruleKey).ID)
+}
+
+func printDeleteRule(ctx *executor.Context) {
+	rb := response.New()
+	if ctx.Error != nil {
+		rb.Error("Error deleting rate limiting rule", ctx.Error).Display()
+		return
+	}
+	rb.FooterSuccessf("Deleted rate limiting rule %s from %s %s", executor.Get(ctx, ruleKey).Label(), executor.Get(ctx, executor.ZoneNameKey), ui.Muted(fmt.Sprintf("(took %v)", ctx.Duration))).Display()
+}
@@ -0,0 +1,168 @@
+package ratelimit
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// planLimits describes the rate limiting features a zone plan includes.
+type planLimits struct {
+	name            string
+	rules           int
+	periods         []int
+	timeouts        []int
+	actions         []string
+	characteristics bool
+}
+
+var (
+	allPeriods       = []int{10, 60, 120, 300, 600, 3600}
+	allTimeouts      = []int{10, 60, 120, 300, 600, 3600, 86400}
+	challengeActions = []string{"managed_challenge", "js_challenge", "challenge"}
+	allActions       = append([]string{"block", "log"}, challengeActions...)
+)
+
+// plans lists the limits of each plan by its legacy ID. Enterprise limits
+// depend on the contract, so they are left to the API to enforce.
+var plans = map[string]planLimits{
+	"free": {
+		name:     "Free",
+		rules:    1,
+		periods:  []int{10},
+		timeouts: []int{10},
+		actions:  []string{"block"},
+	},
+	"pro": {
+		name:     "Pro",
+		rules:    2,
+		periods:  []int{10, 60},
+		timeouts: []int{10, 60},
+		actions:  append([]string{"block"}, challengeActions...),
+	},
+	"business": {
+		name:     "Business",
+		rules:    5,
+		periods:  []int{10, 60, 600},
+		timeouts: []int{10, 60, 600, 3600},
+		actions:  append([]string{"block"}, challengeActions...),
+	},
+	"enterprise": {
+		name:            "Enterprise",
+		periods:         allPeriods,
+		timeouts:        allTimeouts,
+		actions:         allActions,
+		characteristics: true,
+	},
+}
+
+func limitsFor(legacyID string) planLimits {
+	if limits, ok := plans[legacyID]; ok {
+		return limits
+	}
+	return plans["enterprise"]
+}
+
+func formatSeconds(secs []int) string {
+	values := make([]string, len(secs))
+	for i, s := range secs {
+		values[i] = formatDuration(time.Duration(s) * time.Second)
+	}
+	return strings.Join(values, ", ")
+}
+
+// formatDuration renders whole durations compactly, e.g. 10s, 1m or 1h.
+func formatDuration(d time.Duration) string {
+	switch {
+	case d >= time.Hour && d%time.Hour == 0:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	case d >= time.Minute && d%time.Minute == 0:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	default:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	}
+}
+
+// characteristicField maps ip, header:<name>, cookie:<name> and
+// query:<name> to the field the API counts by. Other values are passed
+// through as field names.
+func characteristicField(value string) (string, error) {
+	kind, name, hasName := strings.Cut(value, ":")
+	switch strings.ToLower(kind) {
+	case "ip":
+		return "ip.src", nil
+	case "header":
+		if !hasName || name == "" {
+			return "", fmt.Errorf("characteristic %q needs a header name, e.g. header:x-api-key", value)
+		}
+		return fmt.Sprintf("http.request.headers[%q]", strings.ToLower(name)), nil
+	case "cookie":
+		if !hasName || name == "" {
+			return "", fmt.Errorf("characteristic %q needs a cookie name, e.g. cookie:session", value)
+		}
+		return fmt.Sprintf("http.request.cookies[%q]", name), nil
+	case "query":
+		if !hasName || name == "" {
+			return "", fmt.Errorf("characteristic %q needs a query parameter name, e.g. query:key", value)
+		}
+		return fmt.Sprintf("http.request.uri.args[%q]", name), nil
+	}
+	if strings.Contains(value, ".") {
+		return value, nil
+	}
+	return "", fmt.Errorf("invalid characteristic %q, expected ip, header:<name>, cookie:<name>, query:<name> or a field name", value)
+}
+
+// characteristicLabel reverses characteristicField for display.
+func characteristicLabel(field string) string {
+	if field == "ip.src" {
+		return "ip"
+	}
+	for prefix, kind := range map[string]string{
+		"http.request.headers[":  "header",
+		"http.request.cookies[":  "cookie",
+		"http.request.uri.args[": "query",
+	} {
+		if strings.HasPrefix(field, prefix) && strings.HasSuffix(field, "]") {
+			return kind + ":" + strings.Trim(field[len(prefix):len(field)-1], `"`)
+		}
+	}
+	return field
+}
+
+// validate checks a rule's settings against what the zone's plan allows, so
+// unsupported combinations fail with a clear message before the API call.
+func (p planLimits) validate(fields []string, period, timeout int, action string, existing int) error {
+	if p.rules > 0 && existing >= p.rules {
+		return fmt.Errorf("%s zones allow %d rate limiting rule(s) and this zone already has %d", p.name, p.rules, existing)
+	}
+	if !slices.Contains(allActions, action) {
+		return fmt.Errorf("invalid action: %s. valid actions are: %v", action, allActions)
+	}
+	if !slices.Contains(p.actions, action) {
+		return fmt.Errorf("%s zones support the actions %v, not %s", p.name, p.actions, action)
+	}
+	if !slices.Contains(allPeriods, period) {
+		return fmt.Errorf("invalid --period %s, valid periods are %s", formatDuration(time.Duration(period)*time.Second), formatSeconds(allPeriods))
+	}
+	if !slices.Contains(p.periods, period) {
+		return fmt.Errorf("%s zones support --period %s, not %s", p.name, formatSeconds(p.periods), formatDuration(time.Duration(period)*time.Second))
+	}
+	if action == "block" {
+		if !slices.Contains(allTimeouts, timeout) {
+			return fmt.Errorf("invalid --timeout %s, valid timeouts are %s", formatDuration(time.Duration(timeout)*time.Second), formatSeconds(allTimeouts))
+		}
+		if !slices.Contains(p.timeouts, timeout) {
+			return fmt.Errorf("%s zones support --timeout %s, not %s", p.name, formatSeconds(p.timeouts), formatDuration(time.Duration(timeout)*time.Second))
+		}
+	}
+	if !p.characteristics {
+		for _, field := range fields {
+			if field != "ip.src" && field != "cf.colo.id" {
+				return fmt.Errorf("%s zones can only count requests per IP, counting by %s requires Enterprise with Advanced Rate Limiting", p.name, characteristicLabel(field))
+			}
+		}
+	}
+	return nil
+}
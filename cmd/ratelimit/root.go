@@ -0,0 +1,57 @@
+package ratelimit
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"dario.lol/cf/internal/cloudflare"
+	"dario.lol/cf/internal/executor"
+	"dario.lol/cf/internal/ui"
+	"dario.lol/cf/internal/ui/response"
+	"github.com/spf13/cobra"
+)
+
+var phase = cloudflare.ZonePhase{Name: "http_ratelimit", Command: "rate-limit"}
+
+var (
+	rulesetKey = executor.NewKey[*cloudflare.Ruleset]("rateLimitRuleset")
+	ruleKey    = executor.NewKey[*cloudflare.Rule]("rateLimitRule")
+)
+
+var RateLimitCmd = &cobra.Command{
+	Use:   "rate-limit",
+	Short: "Manage rate limiting rules",
+	Long:  "Rate limiting rules count requests matching an expression per client and act once a client exceeds the allowed rate. They live in the zone's http_ratelimit ruleset.",
+}
+
+func ruleContent(rule cloudflare.Rule) string {
+	enabled := ui.Text("yes")
+	if !rule.IsEnabled() {
+		enabled = ui.Muted("no")
+	}
+	icb := response.NewItemContent().
+		Add("ID:", ui.Text(rule.ID)).
+		Add("Expression:", ui.Text(rule.Expression))
+	if rl := rule.Ratelimit; rl != nil {
+		period := formatDuration(time.Duration(rl.Period) * time.Second)
+		rate := fmt.Sprintf("%d requests per %s", rl.RequestsPerPeriod, period)
+		if rl.RequestsPerPeriod == 0 && rl.ScorePerPeriod > 0 {
+			rate = fmt.Sprintf("score %d per %s", rl.ScorePerPeriod, period)
+		}
+		var counting []string
+		for _, field := range rl.Characteristics {
+			if field != "cf.colo.id" {
+				counting = append(counting, characteristicLabel(field))
+			}
+		}
+		icb.Add("Rate:", ui.Text(rate)).
+			Add("Counting:", ui.Text(strings.Join(counting, ", ")))
+		if rl.MitigationTimeout > 0 {
+			icb.Add("Timeout:", ui.Text(formatDuration(time.Duration(rl.MitigationTimeout)*time.Second)))
+		}
+	}
+	return icb.Add("Action:", ui.Text(rule.Action)).
+		Add("Enabled:", enabled).
+		String()
+}
@@ -0,0 +1,9 @@
+package cmd
+
+import (
+	"dario.lol/cf/cmd/redirects"
+)
+
+func init() {
+	rootCmd.AddCommand(redirects.RedirectsCmd)
+}
@@ -0,0 +1,96 @@
+package redirects
+
+import (
+	"fmt"
+	"slices"
+
+	"dario.lol/cf/internal/cloudflare"
+	"dario.lol/cf/internal/executor"
+	"dario.lol/cf/internal/ui"
+	"dario.lol/cf/internal/ui/response"
+	"github.com/spf13/cobra"
+)
+
+var addCmd = &cobra.Command{
+	Use:   "add <zone>",
+	Short: "Add a single redirect rule",
+	Long:  "Add a single redirect rule. The source is a path, a URL or a rule expression; the target is a static URL or, with --to-expression, an expression such as concat(\"https://example.com\", http.request.uri.path).",
+	Example: `  cf redirects add example.com --from /old-pricing --to https://example.com/pricing
+  cf redirects add example.com --from shop.example.com/sale --to https://example.com/deals --status 302 --preserve-query-string
+  cf redirects add example.com --expression 'http.host eq "www.example.com"' --to-expression 'concat("https://example.com", http.request.uri.path)'`,
+	Args: cobra.ExactArgs(1),
+	Run: executor.New().
+		WithClient().
+		WithZone().
+		Step(executor.NewStep(ruleKey, "Adding redirect").Func(addRule)).
+		Invalidates(executor.PhaseTags(phase)).
+		Display(printAddRule).
+		Run(),
+}
+
+func init() {
+	addCmd.Flags().String("from", "", "Source path (/old) or URL (example.com/old) to redirect")
+	addCmd.Flags().String("expression", "", "Rule expression matching the requests to redirect")
+	addCmd.Flags().String("to", "", "Target URL")
+	addCmd.Flags().String("to-expression", "", "Expression building the target URL")
+	addCmd.Flags().Int("status", 301, fmt.Sprintf("Status code: %v", statusCodes))
+	addCmd.Flags().Bool("preserve-query-string", false, "Keep the query string of the request")
+	addCmd.Flags().String("description", "", "Description of the rule")
+	addCmd.MarkFlagsOneRequired("from", "expression")
+	addCmd.MarkFlagsMutuallyExclusive("from", "expression")
+	addCmd.MarkFlagsOneRequired("to", "to-expression")
+	addCmd.MarkFlagsMutuallyExclusive("to", "to-expression")
+	RedirectsCmd.AddCommand(addCmd)
+}
+
+func addRule(ctx *executor.Context, _ chan<- string) (*cloudflare.Rule, error) {
+	from, _ := ctx.Cmd.Flags().GetString("from")
+	expression, _ := ctx.Cmd.Flags().GetString("expression")
+	to, _ := ctx.Cmd.Flags().GetString("to")
+	toExpression, _ := ctx.Cmd.Flags().GetString("to-expression")
+	status, _ := ctx.Cmd.Flags().GetInt("status")
+	preserveQuery, _ := ctx.Cmd.Flags().GetBool("preserve-query-string")
+	description, _ := ctx.Cmd.Flags().GetString("description")
+
+	if !slices.Contains(statusCodes, status) {
+		return nil, fmt.Errorf("invalid status code: %d. valid status codes are: %v", status, statusCodes)
+	}
+	if from != "" {
+		var err error
+		if expression, err = sourceExpression(from); err != nil {
+			return nil, err
+		}
+	}
+	target := map[string]any{"value": to}
+	if toExpression != "" {
+		target = map[string]any{"expression": toExpression}
+	}
+
+	rule := cloudflare.Rule{
+		Expression:  expression,
+		Action:      "redirect",
+		Description: description,
+		ActionParameters: map[string]any{
+			"from_value": map[string]any{
+				"target_url":            target,
+				"status_code":           status,
+				"preserve_query_string": preserveQuery,
+			},
+		},
+	}
+
+	_, created, err := phase.AddRule(ctx.Client, executor.Get(ctx, executor.ZoneIDKey), rule, cloudflare.RulePosition{})
+	return created, err
+}
+
+func printAddRule(ctx *executor.Context) {
+	rb := response.New()
+	if ctx.Error != nil {
+		rb.Error("Error adding redirect", ctx.Error).Display()
+		return
+	}
+	rule := executor.Get(ctx, ruleKey)
+	rb.AddItem(rule.Title(), ruleContent(*rule)).
+		FooterSuccessf("Added redirect %s to %s %s", rule.ID, executor.Get(ctx, executor.ZoneNameKey), ui.Muted(fmt.Sprintf("(took %v)", ctx.Duration))).
+		Display()
+}
@@ -0,0 +1,252 @@
+package redirects
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"dario.lol/cf/internal/cloudflare"
+	"dario.lol/cf/internal/executor"
+	"dario.lol/cf/internal/flags"
+	"dario.lol/cf/internal/ui"
+	"dario.lol/cf/internal/ui/response"
+	"github.com/spf13/cobra"
+)
+
+const bulkRedirectsPhase = "http_request_redirect"
+
+// defaultBulkTimeout is how long an import waits for the list to update.
+const defaultBulkTimeout = 10 * time.Minute
+
+var isListName = regexp.MustCompile(`^[a-z0-9_]{1,50}$`).MatchString
+
+// csvColumns are the columns of a bulk redirect CSV, in positional order.
+var csvColumns = []string{"source_url", "target_url", "status_code", "preserve_query_string", "include_subdomains", "subpath_matching", "preserve_path_suffix"}
+
+type BulkImport struct {
+	List     *cloudflare.List          `json:"list"`
+	Items    []cloudflare.RedirectItem `json:"items"`
+	Created  bool                      `json:"created"`
+	Attached bool                      `json:"attached"`
+}
+
+var bulkImportKey = executor.NewKey[*BulkImport]("redirectsBulkImport")
+
+var bulkCmd = &cobra.Command{
+	Use:   "bulk",
+	Short: "Manage account-level bulk redirect lists",
+}
+
+var bulkImportCmd = &cobra.Command{
+	Use:   "import <list> <file.csv>",
+	Short: "Import a CSV file into a bulk redirect list",
+	Long: `Import a CSV file into a bulk redirect list, replacing its redirects unless --append is set. The list is created when missing and attached to the account's bulk redirect rules, so the redirects take effect right away.
+
+Columns are ` + strings.Join(csvColumns, ", ") + `. Only the first two are required. A header row naming the columns allows any order.`,
+	Example: `  cf redirects bulk import marketing redirects.csv
+  cf redirects bulk import marketing new.csv --append`,
+	Args: cobra.ExactArgs(2),
+	Run: executor.New().
+		WithClient().
+		WithAccountID().
+		Step(executor.NewStep(bulkImportKey, "Reading redirects").Func(prepareBulkImport)).
+		WithConfirmationFunc(func(ctx *executor.Context) string {
+			bulk := executor.Get(ctx, bulkImportKey)
+			if appendItems, _ := ctx.Cmd.Flags().GetBool("append"); appendItems || bulk.List == nil || bulk.List.NumItems == 0 {
+				return ""
+			}
+			return fmt.Sprintf("Replace the %d redirect(s) in list %s with %d from %s?", bulk.List.NumItems, bulk.List.Name, len(bulk.Items), ctx.Args[1])
+		}).
+		Step(executor.NewStep(bulkImportKey, "Importing redirects").Func(bulkImport)).
+		Display(printBulkImport).
+		Run(),
+}
+
+func init() {
+	flags.RegisterAccountID(bulkCmd)
+	bulkImportCmd.Flags().Bool("append", false, "Add the redirects to the list instead of replacing its contents")
+	bulkImportCmd.Flags().String("description", "", "Description for the list when it is created")
+	bulkImportCmd.Flags().Duration("timeout", defaultBulkTimeout, "How long to wait for the list update to finish")
+	flags.RegisterConfirmation(bulkImportCmd)
+	bulkCmd.AddCommand(bulkImportCmd)
+	RedirectsCmd.AddCommand(bulkCmd)
+}
+
+func isHeader(cell string) bool {
+	cell = strings.ToLower(strings.TrimSpace(cell))
+	return cell == "source_url" || cell == "target_url"
+}
+
+// readRedirectsCSV reads redirects by column position, or by column name
+// when the first row is a header.
+func readRedirectsCSV(r io.Reader) ([]cloudflare.RedirectItem, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	columns, line := csvColumns, 1
+	if len(records) > 0 && slices.ContainsFunc(records[0], isHeader) {
+		columns = make([]string, len(records[0]))
+		for i, name := range records[0] {
+			columns[i] = strings.ToLower(strings.TrimSpace(name))
+		}
+		records, line = records[1:], 2
+	}
+
+	items := make([]cloudflare.RedirectItem, 0, len(records))
+	for i, record := range records {
+		var redirect cloudflare.Redirect
+		for j, value := range record {
+			if j >= len(columns) || strings.TrimSpace(value) == "" {
+				continue
+			}
+			value = strings.TrimSpace(value)
+			var err error
+			switch columns[j] {
+			case "source_url":
+				redirect.SourceURL = value
+			case "target_url":
+				redirect.TargetURL = value
+			case "status_code":
+				redirect.StatusCode, err = strconv.Atoi(value)
+			case "preserve_query_string":
+				redirect.PreserveQueryString, err = strconv.ParseBool(value)
+			case "include_subdomains":
+				redirect.IncludeSubdomains, err = strconv.ParseBool(value)
+			case "subpath_matching":
+				redirect.SubpathMatching, err = strconv.ParseBool(value)
+			case "preserve_path_suffix":
+				redirect.PreservePathSuffix, err = strconv.ParseBool(value)
+			default:
+				return nil, fmt.Errorf("unknown column %q, expected one of %v", columns[j], csvColumns)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid %s %q", line+i, columns[j], value)
+			}
+		}
+		if redirect.SourceURL == "" || redirect.TargetURL == "" {
+			return nil, fmt.Errorf("line %d: source_url and target_url are required", line+i)
+		}
+		items = append(items, cloudflare.RedirectItem{Redirect: redirect})
+	}
+	if len(items) == 0 {
+		return nil, fmt.Errorf("the file contains no redirects")
+	}
+	return items, nil
+}
+
+func prepareBulkImport(ctx *executor.Context, progress chan<- string) (*BulkImport, error) {
+	name := ctx.Args[0]
+	if !isListName(name) {
+		return nil, fmt.Errorf("invalid list name %q, use up to 50 lowercase letters, digits and underscores", name)
+	}
+
+	file, err := os.Open(ctx.Args[1])
+	if err != nil {
+		return nil, fmt.Errorf("error opening file: %w", err)
+	}
+	defer file.Close()
+	items, err := readRedirectsCSV(file)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", ctx.Args[1], err)
+	}
+
+	progress <- "Looking up list"
+	list, err := cloudflare.FindList(ctx.Client, ctx.AccountID, name)
+	if err != nil {
+		return nil, err
+	}
+	if list != nil && list.Kind != "redirect" {
+		return nil, fmt.Errorf("list %s holds %s items, not redirects", name, list.Kind)
+	}
+	return &BulkImport{List: list, Items: items}, nil
+}
+
+// bulkImport uploads the redirects, creating the list first if needed, and
+// adds a rule enabling the list unless the account ruleset already has one.
+func bulkImport(ctx *executor.Context, progress chan<- string) (*BulkImport, error) {
+	bulk := executor.Get(ctx, bulkImportKey)
+	name := ctx.Args[0]
+	timeout, _ := ctx.Cmd.Flags().GetDuration("timeout")
+	if timeout <= 0 {
+		return nil, fmt.Errorf("--timeout must be positive")
+	}
+
+	if bulk.List == nil {
+		progress <- fmt.Sprintf("Creating list %s", name)
+		description, _ := ctx.Cmd.Flags().GetString("description")
+		list, err := cloudflare.CreateList(ctx.Client, ctx.AccountID, cloudflare.List{Name: name, Kind: "redirect", Description: description})
+		if err != nil {
+			return nil, err
+		}
+		bulk.List, bulk.Created = list, true
+	}
+
+	progress <- fmt.Sprintf("Uploading %d redirect(s)", len(bulk.Items))
+	appendItems, _ := ctx.Cmd.Flags().GetBool("append")
+	if err := cloudflare.ReplaceRedirectItems(ctx.Client, ctx.AccountID, bulk.List.ID, bulk.Items, !appendItems, timeout); err != nil {
+		return nil, err
+	}
+
+	progress <- "Attaching list"
+	scope := cloudflare.AccountScope(ctx.AccountID)
+	ruleset, err := cloudflare.GetPhaseEntrypoint(ctx.Client, scope, bulkRedirectsPhase)
+	if err != nil {
+		return nil, err
+	}
+	for _, rule := range ruleset.Rules {
+		if fromList, ok := rule.ActionParameters["from_list"].(map[string]any); ok && fromList["name"] == name {
+			return bulk, nil
+		}
+	}
+	_, _, err = cloudflare.AddPhaseRule(ctx.Client, scope, bulkRedirectsPhase, cloudflare.Rule{
+		Expression:  fmt.Sprintf("http.request.full_uri in $%s", name),
+		Action:      "redirect",
+		Description: "Bulk redirects from " + name,
+		ActionParameters: map[string]any{
+			"from_list": map[string]any{"name": name, "key": "http.request.full_uri"},
+		},
+	}, cloudflare.RulePosition{})
+	if err != nil {
+		return nil, err
+	}
+	bulk.Attached = true
+	return bulk, nil
+}
+
+func printBulkImport(ctx *executor.Context) {
+	rb := response.New()
+	if ctx.Error != nil {
+		rb.Error("Error importing redirects", ctx.Error).Display()
+		return
+	}
+
+	bulk := executor.Get(ctx, bulkImportKey)
+	icb := response.NewItemContent().
+		Add("ID:", ui.Text(bulk.List.ID)).
+		Add("Redirects:", ui.Text(fmt.Sprint(len(bulk.Items))))
+	if bulk.Created {
+		icb.Add("List:", ui.Text("created"))
+	}
+	if bulk.Attached {
+		icb.Add("Rule:", ui.Text("added to the account's bulk redirect rules"))
+	}
+
+	verb := "Replaced the redirects in"
+	if appendItems, _ := ctx.Cmd.Flags().GetBool("append"); appendItems {
+		verb = "Added redirects to"
+	}
+	rb.AddItem(bulk.List.Name, icb.String()).
+		FooterSuccessf("%s list %s %s", verb, bulk.List.Name, ui.Muted(fmt.Sprintf("(took %v)", ctx.Duration))).
+		Display()
+}
@@ -0,0 +1,47 @@
+package redirects
+
+import (
+	"fmt"
+
+	"dario.lol/cf/internal/cloudflare"
+	"dario.lol/cf/internal/executor"
+	"dario.lol/cf/internal/flags"
+	"dario.lol/cf/internal/ui"
+	"dario.lol/cf/internal/ui/response"
+	"github.com/spf13/cobra"
+)
+
+var removeCmd = &cobra.Command{
+	Use:   "remove <zone> <rule>",
+	Short: "Remove a redirect rule by ID or description",
+	Args:  cobra.ExactArgs(2),
+	Run: executor.New().
+		WithClient().
+		WithZone().
+		Step(executor.NewStep(ruleKey, "Fetching redirects").Func(executor.PhaseRule(phase, rulesetKey))).
+		WithConfirmationFunc(func(ctx *executor.Context) string {
+			return fmt.Sprintf("Are you sure you want to remove redirect %s in zone %s?", executor.Get(ctx, ruleKey).Label(), executor.Get(ctx, executor.ZoneNameKey))
+		}).
+		Step(executor.NewStep(rulesetKey, "Removing redirect").Func(removeRule)).
+		Invalidates(executor.PhaseTags(phase)).
+		Display(printRemoveRule).
+		Run(),
+}
+
+func init() {
+	flags.RegisterConfirmation(removeCmd)
+	RedirectsCmd.AddCommand(removeCmd)
+}
+
+func removeRule(ctx *executor.Context, _ chan<- string) (*cloudflare.Ruleset, error) {
+	return cloudflare.DeleteRule(ctx.Client, cloudflare.ZoneScope(executor.Get(ctx, executor.ZoneIDKey)), executor.Get(ctx, rulesetKey).ID, executor.Get(ctx, ruleKey).ID)
+}
+
+func printRemoveRule(ctx *executor.Context) {
+	rb := response.New()
+	if ctx.Error != nil {
+		rb.Error("Error removing redirect", ctx.Error).Display()
+		return
+	}
+	rb.FooterSuccessf("Removed redirect %s from %s %s", executor.Get(ctx, ruleKey).Label(), executor.Get(ctx, executor.ZoneNameKey), ui.Muted(fmt.Sprintf("(took %v)", ctx.Duration))).Display()
+}
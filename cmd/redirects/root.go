@@ -0,0 +1,89 @@
+package redirects
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"dario.lol/cf/internal/cloudflare"
+	"dario.lol/cf/internal/executor"
+	"dario.lol/cf/internal/ui"
+	"dario.lol/cf/internal/ui/response"
+	"github.com/spf13/cobra"
+)
+
+var phase = cloudflare.ZonePhase{Name: "http_request_dynamic_redirect", Command: "redirects"}
+
+var statusCodes = []int{301, 302, 303, 307, 308}
+
+var (
+	rulesetKey = executor.NewKey[*cloudflare.Ruleset]("redirectsRuleset")
+	ruleKey    = executor.NewKey[*cloudflare.Rule]("redirectsRule")
+)
+
+var RedirectsCmd = &cobra.Command{
+	Use:   "redirects",
+	Short: "Manage single and bulk redirects",
+	Long:  "Single redirect rules live in the zone's http_request_dynamic_redirect ruleset and match requests by expression. Bulk redirects are account-level lists of source and target URLs.",
+}
+
+// sourceExpression matches a path such as /old, or a URL such as
+// https://example.com/old on its host and path.
+func sourceExpression(from string) (string, error) {
+	if strings.HasPrefix(from, "/") {
+		return fmt.Sprintf("http.request.uri.path eq %q", from), nil
+	}
+	if !strings.Contains(from, "://") {
+		from = "https://" + from
+	}
+	u, err := url.Parse(from)
+	if err != nil || u.Hostname() == "" {
+		return "", fmt.Errorf("invalid --from %q, expected a path such as /old or a URL such as example.com/old", from)
+	}
+	path := u.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	return fmt.Sprintf("(http.host eq %q and http.request.uri.path eq %q)", u.Hostname(), path), nil
+}
+
+// redirectTarget returns the target and whether it is a dynamic expression.
+func redirectTarget(rule cloudflare.Rule) (string, bool) {
+	from, _ := rule.ActionParameters["from_value"].(map[string]any)
+	target, _ := from["target_url"].(map[string]any)
+	if value, ok := target["value"].(string); ok {
+		return value, false
+	}
+	expression, _ := target["expression"].(string)
+	return expression, true
+}
+
+func ruleContent(rule cloudflare.Rule) string {
+	from, _ := rule.ActionParameters["from_value"].(map[string]any)
+	target, dynamic := redirectTarget(rule)
+	icb := response.NewItemContent().
+		Add("ID:", ui.Text(rule.ID)).
+		Add("From:", ui.Text(rule.Expression))
+	if dynamic {
+		icb.Add("To:", ui.Text(target)+" "+ui.Muted("(expression)"))
+	} else {
+		icb.Add("To:", ui.Text(target))
+	}
+	status := 301
+	switch code := from["status_code"].(type) {
+	case float64:
+		status = int(code)
+	case int:
+		status = code
+	}
+	query := "dropped"
+	if preserve, _ := from["preserve_query_string"].(bool); preserve {
+		query = "preserved"
+	}
+	icb.Add("Status:", ui.Text(fmt.Sprint(status))).
+		Add("Query string:", ui.Text(query))
+	if !rule.IsEnabled() {
+		icb.Add("Enabled:", ui.Muted("no"))
+	}
+	return icb.String()
+}
@@ -7,6 +7,7 @@ import (
 	"os"
 
 	"dario.lol/cf/internal/constants"
+	"dario.lol/cf/internal/flags"
 	"dario.lol/cf/internal/ui"
 	"github.com/charmbracelet/fang"
 	"github.com/charmbracelet/lipgloss/v2"
@@ -20,6 +21,10 @@ var rootCmd = &cobra.Command{
 	Version: constants.Version,
 }
 
+func init() {
+	flags.RegisterRevalidate(rootCmd)
+}
+
 func configureColorScheme(_ lipgloss.LightDarkFunc) fang.ColorScheme {
 	return ui.FangTheme()
 }
@@ -0,0 +1,9 @@
+package cmd
+
+import (
+	"dario.lol/cf/cmd/rules"
+)
+
+func init() {
+	rootCmd.AddCommand(rules.RulesCmd)
+}
@@ -0,0 +1,158 @@
+package rules
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"dario.lol/cf/internal/cloudflare"
+	"dario.lol/cf/internal/ui"
+)
+
+const (
+	changeCreate  = "create"
+	changeUpdate  = "update"
+	changeDelete  = "delete"
+	changeReorder = "reorder"
+)
+
+type Change struct {
+	Kind   string          `json:"kind"`
+	Rule   cloudflare.Rule `json:"rule"`
+	Fields []string        `json:"fields,omitempty"`
+}
+
+// Plan is the set of changes an import makes, and the ruleset it uploads.
+type Plan struct {
+	Phase   string             `json:"phase"`
+	Changes []Change           `json:"changes"`
+	Ruleset cloudflare.Ruleset `json:"ruleset"`
+}
+
+// planImport matches the desired rules to the current ones by ID, then by
+// ref, and lists what has to be created, updated, deleted or reordered.
+func planImport(current, desired *cloudflare.Ruleset) (*Plan, error) {
+	plan := &Plan{Phase: current.Phase, Ruleset: cloudflare.Ruleset{Description: desired.Description}}
+	matched := map[string]bool{}
+	var order []string
+
+	for i, rule := range desired.Rules {
+		existing := matchRule(current, rule)
+		if existing == nil {
+			rule.ID = ""
+			plan.Changes = append(plan.Changes, Change{Kind: changeCreate, Rule: rule})
+			plan.Ruleset.Rules = append(plan.Ruleset.Rules, rule)
+			continue
+		}
+		if matched[existing.ID] {
+			return nil, fmt.Errorf("rules[%d] (%s) matches rule %s, which an earlier rule already matched", i, rule.Title(), existing.ID)
+		}
+		matched[existing.ID] = true
+		order = append(order, existing.ID)
+
+		rule.ID = existing.ID
+		if fields := changedFields(*existing, rule); len(fields) > 0 {
+			plan.Changes = append(plan.Changes, Change{Kind: changeUpdate, Rule: rule, Fields: fields})
+		}
+		plan.Ruleset.Rules = append(plan.Ruleset.Rules, rule)
+	}
+
+	var kept []string
+	for _, rule := range current.Rules {
+		if matched[rule.ID] {
+			kept = append(kept, rule.ID)
+			continue
+		}
+		plan.Changes = append(plan.Changes, Change{Kind: changeDelete, Rule: rule})
+	}
+	if !slices.Equal(kept, order) {
+		plan.Changes = append(plan.Changes, Change{Kind: changeReorder})
+	}
+	if desired.Description != "" && desired.Description != current.Description && current.ID != "" {
+		plan.Changes = append(plan.Changes, Change{Kind: changeUpdate, Fields: []string{"description"}})
+	}
+	return plan, nil
+}
+
+func matchRule(current *cloudflare.Ruleset, rule cloudflare.Rule) *cloudflare.Rule {
+	for i := range current.Rules {
+		existing := &current.Rules[i]
+		if rule.ID != "" && existing.ID == rule.ID {
+			return existing
+		}
+		if rule.ID == "" && rule.Ref != "" && existing.Ref == rule.Ref {
+			return existing
+		}
+	}
+	return nil
+}
+
+// normalizeRule turns a rule into plain JSON values so rules decoded from the
+// API and from YAML compare equal, e.g. 3600 and 3600.0.
+func normalizeRule(rule cloudflare.Rule) map[string]any {
+	enabled := rule.IsEnabled()
+	rule.ID, rule.Version, rule.LastUpdated, rule.Enabled = "", "", "", &enabled
+	data, _ := json.Marshal(rule)
+	var m map[string]any
+	_ = json.Unmarshal(data, &m)
+	return m
+}
+
+func changedFields(current, desired cloudflare.Rule) []string {
+	a, b := normalizeRule(current), normalizeRule(desired)
+	var fields []string
+	for key := range a {
+		if !reflect.DeepEqual(a[key], b[key]) {
+			fields = append(fields, key)
+		}
+	}
+	for key := range b {
+		if _, ok := a[key]; !ok {
+			fields = append(fields, key)
+		}
+	}
+	slices.Sort(fields)
+	return fields
+}
+
+// String renders the plan one change per line, e.g. "~ Block bots (expression)".
+func (p *Plan) String() string {
+	var lines []string
+	for _, c := range p.Changes {
+		switch c.Kind {
+		case changeCreate:
+			lines = append(lines, ui.StatusSuccess.Render(fmt.Sprintf("+ %s", c.Rule.Title()))+ui.Muted(fmt.Sprintf(" (%s)", c.Rule.Action)))
+		case changeUpdate:
+			title := "ruleset"
+			if c.Rule.ID != "" {
+				title = c.Rule.Title()
+			}
+			lines = append(lines, ui.StatusWarning.Render(fmt.Sprintf("~ %s", title))+ui.Muted(fmt.Sprintf(" (%s)", strings.Join(c.Fields, ", "))))
+		case changeDelete:
+			lines = append(lines, ui.StatusError.Render(fmt.Sprintf("- %s", c.Rule.Title()))+ui.Muted(fmt.Sprintf(" (%s)", c.Rule.ID)))
+		case changeReorder:
+			lines = append(lines, ui.StatusWarning.Render("↕ rules reordered"))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Summary counts the changes, e.g. "1 to create, 2 to update".
+func (p *Plan) Summary() string {
+	counts := map[string]int{}
+	for _, c := range p.Changes {
+		counts[c.Kind]++
+	}
+	var parts []string
+	for _, kind := range []string{changeCreate, changeUpdate, changeDelete} {
+		if counts[kind] > 0 {
+			parts = append(parts, fmt.Sprintf("%d to %s", counts[kind], kind))
+		}
+	}
+	if counts[changeReorder] > 0 {
+		parts = append(parts, "new order")
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,102 @@
+package rules
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"dario.lol/cf/internal/cloudflare"
+	"gopkg.in/yaml.v3"
+)
+
+var formats = []string{"yaml", "json"}
+
+// document strips the server-managed fields of a ruleset so exports only
+// change when the rules do. Rule IDs are kept so imports update rules in
+// place instead of recreating them.
+func document(ruleset *cloudflare.Ruleset) cloudflare.Ruleset {
+	doc := cloudflare.Ruleset{
+		Phase:       ruleset.Phase,
+		Description: ruleset.Description,
+		Rules:       make([]cloudflare.Rule, len(ruleset.Rules)),
+	}
+	for i, rule := range ruleset.Rules {
+		rule.Version, rule.LastUpdated = "", ""
+		doc.Rules[i] = rule
+	}
+	return doc
+}
+
+// outputFormat picks the --format value, else the output file's extension,
+// else YAML.
+func outputFormat(format, output string) (string, error) {
+	if format == "" {
+		format = "yaml"
+		if strings.EqualFold(filepath.Ext(output), ".json") {
+			format = "json"
+		}
+	}
+	if format == "yml" {
+		format = "yaml"
+	}
+	if format != "yaml" && format != "json" {
+		return "", fmt.Errorf("invalid format: %s. valid formats are: %v", format, formats)
+	}
+	return format, nil
+}
+
+func encodeDocument(w io.Writer, doc any, format string) error {
+	if format == "json" {
+		encoder := json.NewEncoder(w)
+		encoder.SetEscapeHTML(false)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(doc)
+	}
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(doc); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+func openInput(path string) (io.ReadCloser, error) {
+	if path == "" || path == "-" {
+		return io.NopCloser(os.Stdin), nil
+	}
+	return os.Open(path)
+}
+
+// readDocument parses a ruleset exported as JSON or YAML.
+func readDocument(r io.Reader) (*cloudflare.Ruleset, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	var doc cloudflare.Ruleset
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		decoder := json.NewDecoder(bytes.NewReader(trimmed))
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(&doc)
+	} else {
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		err = decoder.Decode(&doc)
+		if err == io.EOF {
+			err = fmt.Errorf("the file is empty")
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	for i, rule := range doc.Rules {
+		if rule.Expression == "" || rule.Action == "" {
+			return nil, fmt.Errorf("rules[%d] (%s) needs an expression and an action", i, rule.Title())
+		}
+	}
+	return &doc, nil
+}
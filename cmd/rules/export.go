@@ -0,0 +1,67 @@
+package rules
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"dario.lol/cf/internal/executor"
+	"dario.lol/cf/internal/ui"
+	"dario.lol/cf/internal/ui/response"
+	"github.com/spf13/cobra"
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export <zone>",
+	Short: "Export the rules of a phase as YAML or JSON",
+	Long:  "Export the rules of a phase as YAML or JSON. The output can be kept in git, edited and applied again with `cf rules import`.",
+	Example: `  cf rules export example.com --phase redirect > redirects.yaml
+  cf rules export example.com --phase waf -o waf.json`,
+	Args: cobra.ExactArgs(1),
+	Run: executor.New().
+		WithClient().
+		WithZone().
+		Step(executor.NewStep(rulesetKey, "Exporting rules").Func(getRules)).
+		Display(printExport).
+		Run(),
+}
+
+func init() {
+	exportCmd.Flags().StringP("output", "o", "", "Write the export to a file instead of stdout")
+	exportCmd.Flags().String("format", "", "Output format: yaml or json (default: from the file extension, else yaml)")
+	RulesCmd.AddCommand(exportCmd)
+}
+
+func printExport(ctx *executor.Context) {
+	if ctx.Error != nil {
+		response.New().Error("Error exporting rules", ctx.Error).Display()
+		return
+	}
+
+	output, _ := ctx.Cmd.Flags().GetString("output")
+	format, _ := ctx.Cmd.Flags().GetString("format")
+	format, err := outputFormat(format, output)
+	if err != nil {
+		response.New().Error("Error exporting rules", err).Display()
+		return
+	}
+
+	var out io.Writer = os.Stdout
+	if output != "" {
+		file, err := os.Create(output)
+		if err != nil {
+			response.New().Error("Error creating output file", err).Display()
+			return
+		}
+		defer file.Close()
+		out = file
+	}
+
+	ruleset := executor.Get(ctx, rulesetKey)
+	if err := encodeDocument(out, document(ruleset), format); err != nil {
+		response.New().Error("Error writing export", err).Display()
+		return
+	}
+
+	fmt.Fprintln(os.Stderr, ui.Success(fmt.Sprintf("Exported %d %s rule(s) from %s %s", len(ruleset.Rules), ruleset.Phase, executor.Get(ctx, executor.ZoneNameKey), ui.Muted(fmt.Sprintf("(took %v)", ctx.Duration)))))
+}
@@ -0,0 +1,57 @@
+package rules
+
+import (
+	"fmt"
+	"os"
+
+	"dario.lol/cf/internal/cloudflare"
+	"dario.lol/cf/internal/executor"
+	"dario.lol/cf/internal/ui/response"
+	"github.com/spf13/cobra"
+)
+
+var getCmd = &cobra.Command{
+	Use:     "get <zone> <rule>",
+	Short:   "Print a rule by ID, ref or description",
+	Long:    "Print a rule with all its parameters as YAML or JSON, in the same shape `cf rules export` uses.",
+	Example: `  cf rules get example.com "Redirect blog" --phase redirect --format json`,
+	Args:    cobra.ExactArgs(2),
+	Run: executor.New().
+		WithClient().
+		WithZone().
+		Step(executor.NewStep(ruleKey, "Fetching rules").Func(findRule)).
+		Display(printRule).
+		Run(),
+}
+
+func init() {
+	getCmd.Flags().String("format", "yaml", fmt.Sprintf("Output format: %v", formats))
+	RulesCmd.AddCommand(getCmd)
+}
+
+func findRule(ctx *executor.Context, _ chan<- string) (*cloudflare.Rule, error) {
+	ruleset, err := getRules(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	executor.Set(ctx, rulesetKey, ruleset)
+	return cloudflare.FindRule(ruleset, ctx.Args[1])
+}
+
+func printRule(ctx *executor.Context) {
+	if ctx.Error != nil {
+		response.New().Error("Error fetching rule", ctx.Error).Display()
+		return
+	}
+	format, _ := ctx.Cmd.Flags().GetString("format")
+	format, err := outputFormat(format, "")
+	if err != nil {
+		response.New().Error("Error printing rule", err).Display()
+		return
+	}
+	rule := *executor.Get(ctx, ruleKey)
+	rule.Version, rule.LastUpdated = "", ""
+	if err := encodeDocument(os.Stdout, rule, format); err != nil {
+		response.New().Error("Error printing rule", err).Display()
+	}
+}
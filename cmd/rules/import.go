@@ -0,0 +1,121 @@
+package rules
+
+import (
+	"fmt"
+
+	"dario.lol/cf/internal/cloudflare"
+	"dario.lol/cf/internal/executor"
+	"dario.lol/cf/internal/flags"
+	"dario.lol/cf/internal/ui"
+	"dario.lol/cf/internal/ui/response"
+	"github.com/spf13/cobra"
+)
+
+var planKey = executor.NewKey[*Plan]("rulesPlan")
+
+var importCmd = &cobra.Command{
+	Use:   "import <zone> [file]",
+	Short: "Apply a ruleset exported with `cf rules export`",
+	Long: `Apply a YAML or JSON ruleset exported with ` + "`cf rules export`" + `, replacing all rules of the phase. Reads from stdin when no file is given.
+
+Rules are matched to the current ones by ID, then by ref. The changes are shown before anything is applied; use --dry-run to only show them. The phase is taken from --phase or from the file.`,
+	Example: `  cf rules import example.com redirects.yaml --dry-run
+  cf rules import example.com waf.json --phase waf --yes`,
+	Args: cobra.RangeArgs(1, 2),
+	Run: executor.New().
+		WithClient().
+		WithZone().
+		Step(executor.NewStep(planKey, "Planning changes").Func(planRules)).
+		WithConfirmationFunc(func(ctx *executor.Context) string {
+			plan := executor.Get(ctx, planKey)
+			if dryRun, _ := ctx.Cmd.Flags().GetBool("dry-run"); dryRun || len(plan.Changes) == 0 {
+				return ""
+			}
+			return fmt.Sprintf("%s\n\nApply these changes (%s) to the %s rules of %s?", plan, plan.Summary(), plan.Phase, executor.Get(ctx, executor.ZoneNameKey))
+		}).
+		Step(executor.NewStep(rulesetKey, "Applying changes").Func(applyPlan)).
+		Invalidates(func(ctx *executor.Context) []string {
+			return []string{cloudflare.RulesCacheTag(executor.Get(ctx, executor.ZoneIDKey), executor.Get(ctx, planKey).Phase)}
+		}).
+		Display(printImport).
+		Run(),
+}
+
+func init() {
+	importCmd.Flags().Bool("dry-run", false, "Show the changes without applying them")
+	flags.RegisterConfirmation(importCmd)
+	RulesCmd.AddCommand(importCmd)
+}
+
+func planRules(ctx *executor.Context, progress chan<- string) (*Plan, error) {
+	path := "-"
+	if len(ctx.Args) > 1 {
+		path = ctx.Args[1]
+	}
+	input, err := openInput(path)
+	if err != nil {
+		return nil, fmt.Errorf("error opening file: %w", err)
+	}
+	defer input.Close()
+
+	desired, err := readDocument(input)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", path, err)
+	}
+
+	value, _ := ctx.Cmd.Flags().GetString("phase")
+	p := resolvePhase(value)
+	switch {
+	case p == "" && desired.Phase == "":
+		return nil, fmt.Errorf("the file names no phase, pass --phase")
+	case p == "":
+		p = desired.Phase
+	case desired.Phase != "" && desired.Phase != p:
+		return nil, fmt.Errorf("the file contains %s rules, not %s", desired.Phase, p)
+	}
+
+	progress <- "Fetching current rules"
+	current, err := cloudflare.GetPhaseEntrypoint(ctx.Client, cloudflare.ZoneScope(executor.Get(ctx, executor.ZoneIDKey)), p)
+	if err != nil {
+		return nil, err
+	}
+	plan, err := planImport(current, desired)
+	if err != nil {
+		return nil, err
+	}
+	plan.Phase = p
+	return plan, nil
+}
+
+func applyPlan(ctx *executor.Context, _ chan<- string) (*cloudflare.Ruleset, error) {
+	plan := executor.Get(ctx, planKey)
+	if dryRun, _ := ctx.Cmd.Flags().GetBool("dry-run"); dryRun || len(plan.Changes) == 0 {
+		return nil, nil
+	}
+	return cloudflare.UpdatePhaseEntrypoint(ctx.Client, cloudflare.ZoneScope(executor.Get(ctx, executor.ZoneIDKey)), plan.Phase, plan.Ruleset)
+}
+
+func printImport(ctx *executor.Context) {
+	rb := response.New()
+	if ctx.Error != nil {
+		rb.Error("Error importing rules", ctx.Error).Display()
+		return
+	}
+
+	plan := executor.Get(ctx, planKey)
+	zoneName := executor.Get(ctx, executor.ZoneNameKey)
+	took := ui.Muted(fmt.Sprintf("(took %v)", ctx.Duration))
+	if len(plan.Changes) == 0 {
+		rb.FooterSuccessf("The %s rules of %s are up to date %s", plan.Phase, zoneName, took).Display()
+		return
+	}
+	if dryRun, _ := ctx.Cmd.Flags().GetBool("dry-run"); dryRun {
+		rb.AddItem("Planned changes", plan.String()).
+			FooterSuccessf("Dry run: %s in the %s rules of %s %s", plan.Summary(), plan.Phase, zoneName, took).
+			Display()
+		return
+	}
+	rb.AddItem("Applied changes", plan.String()).
+		FooterSuccessf("Imported %d %s rule(s) into %s %s", len(plan.Ruleset.Rules), plan.Phase, zoneName, took).
+		Display()
+}
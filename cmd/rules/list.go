@@ -0,0 +1,52 @@
+package rules
+
+import (
+	"fmt"
+
+	"dario.lol/cf/internal/executor"
+	"dario.lol/cf/internal/ui"
+	"dario.lol/cf/internal/ui/response"
+	"github.com/spf13/cobra"
+)
+
+var listCmd = &cobra.Command{
+	Use:     "list <zone>",
+	Short:   "List the rules of a phase",
+	Example: `  cf rules list example.com --phase redirect`,
+	Args:    cobra.ExactArgs(1),
+	Run: executor.New().
+		WithClient().
+		WithZone().
+		WithNoCache().
+		Step(executor.NewStep(rulesetKey, "Fetching rules").
+			Func(getRules).
+			CacheKeyFunc(rulesCacheKey)).
+		Display(printRules).
+		Run(),
+}
+
+func init() {
+	listCmd.Flags().Bool("no-cache", false, "Bypass the cache and fetch directly from the API")
+	RulesCmd.AddCommand(listCmd)
+}
+
+func printRules(ctx *executor.Context) {
+	rb := response.New()
+	if ctx.Error != nil {
+		rb.Error("Error fetching rules", ctx.Error).Display()
+		return
+	}
+
+	ruleset := executor.Get(ctx, rulesetKey)
+	zoneName := executor.Get(ctx, executor.ZoneNameKey)
+	if len(ruleset.Rules) == 0 {
+		rb.FooterSuccessf("No %s rules found for %s", ruleset.Phase, zoneName).Display()
+		return
+	}
+
+	for i, rule := range ruleset.Rules {
+		rb.AddItem(fmt.Sprintf("%d. %s", i+1, rule.Title()), ruleContent(rule))
+	}
+
+	rb.FooterSuccessf("Found %d %s rule(s) for %s %s", len(ruleset.Rules), ruleset.Phase, zoneName, ui.Muted(fmt.Sprintf("(took %v)", ctx.Duration))).Display()
+}
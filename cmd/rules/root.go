@@ -0,0 +1,98 @@
+package rules
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"dario.lol/cf/internal/cloudflare"
+	"dario.lol/cf/internal/executor"
+	"dario.lol/cf/internal/ui"
+	"dario.lol/cf/internal/ui/response"
+	"github.com/spf13/cobra"
+)
+
+// phaseAliases are short names for the zone phases most rules live in.
+var phaseAliases = map[string]string{
+	"waf":              "http_request_firewall_custom",
+	"rate-limit":       "http_ratelimit",
+	"cache":            "http_request_cache_settings",
+	"redirect":         "http_request_dynamic_redirect",
+	"rewrite":          "http_request_transform",
+	"request-headers":  "http_request_late_transform",
+	"response-headers": "http_response_headers_transform",
+	"origin":           "http_request_origin",
+	"config":           "http_config_settings",
+	"compression":      "http_response_compression",
+	"custom-errors":    "http_custom_errors",
+}
+
+var (
+	rulesetKey = executor.NewKey[*cloudflare.Ruleset]("rulesRuleset")
+	ruleKey    = executor.NewKey[*cloudflare.Rule]("rulesRule")
+)
+
+var RulesCmd = &cobra.Command{
+	Use:   "rules",
+	Short: "Manage the rules of any ruleset phase",
+	Long: `Manage the rules of any Rulesets API phase of a zone, such as WAF custom rules, rate limits, cache rules, redirects, URL rewrites and header transforms. Rulesets can be exported to YAML or JSON, kept in git and imported again.
+
+--phase takes a phase name such as http_request_dynamic_redirect or one of these aliases: ` + strings.Join(aliasNames(), ", ") + `.`,
+}
+
+func init() {
+	RulesCmd.PersistentFlags().String("phase", "", "Ruleset phase, e.g. http_request_dynamic_redirect or an alias such as redirect")
+}
+
+func aliasNames() []string {
+	names := make([]string, 0, len(phaseAliases))
+	for name := range phaseAliases {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// resolvePhase expands a phase alias. Unknown names are passed through so
+// new phases work without changes here.
+func resolvePhase(phase string) string {
+	if full, ok := phaseAliases[phase]; ok {
+		return full
+	}
+	return phase
+}
+
+// phase returns the phase selected with --phase.
+func phase(ctx *executor.Context) (cloudflare.ZonePhase, error) {
+	value, _ := ctx.Cmd.Flags().GetString("phase")
+	if value == "" {
+		return cloudflare.ZonePhase{}, fmt.Errorf("--phase is required, e.g. --phase redirect or --phase http_request_dynamic_redirect")
+	}
+	return cloudflare.ZonePhase{Name: resolvePhase(value), Command: "rules"}, nil
+}
+
+func rulesCacheKey(ctx *executor.Context) string {
+	p, _ := phase(ctx)
+	return p.CacheKey(executor.Get(ctx, executor.ZoneIDKey))
+}
+
+func getRules(ctx *executor.Context, _ chan<- string) (*cloudflare.Ruleset, error) {
+	p, err := phase(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return p.Rules(ctx.Client, executor.Get(ctx, executor.ZoneIDKey))
+}
+
+func ruleContent(rule cloudflare.Rule) string {
+	enabled := ui.Text("yes")
+	if !rule.IsEnabled() {
+		enabled = ui.Muted("no")
+	}
+	return response.NewItemContent().
+		Add("ID:", ui.Text(rule.ID)).
+		Add("Action:", ui.Text(rule.Action)).
+		Add("Expression:", ui.Text(rule.Expression)).
+		Add("Enabled:", enabled).
+		String()
+}
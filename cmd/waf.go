@@ -0,0 +1,9 @@
+package cmd
+
+import (
+	"dario.lol/cf/cmd/waf"
+)
+
+func init() {
+	rootCmd.AddCommand(waf.WafCmd)
+}
@@ -0,0 +1,77 @@
+package waf
+
+import (
+	"fmt"
+
+	"dario.lol/cf/internal/cloudflare"
+	"dario.lol/cf/internal/executor"
+	"dario.lol/cf/internal/ui"
+	"dario.lol/cf/internal/ui/response"
+	"github.com/spf13/cobra"
+)
+
+var createCmd = &cobra.Command{
+	Use:   "create <zone>",
+	Short: "Create a WAF custom rule",
+	Example: `  cf waf create example.com --expression 'ip.src.country eq "T1"' --action block --description "Block Tor"
+  cf waf create example.com --expression 'http.request.uri.path contains "/wp-login"' --action managed_challenge --position first`,
+	Args: cobra.ExactArgs(1),
+	Run: executor.New().
+		WithClient().
+		WithZone().
+		Step(executor.NewStep(ruleKey, "Creating WAF rule").Func(createRule)).
+		Invalidates(executor.PhaseTags(phase)).
+		Display(printCreateRule).
+		Run(),
+}
+
+func init() {
+	createCmd.Flags().String("expression", "", "Expression matching the requests the rule applies to")
+	createCmd.Flags().String("action", "block", fmt.Sprintf("Action to take: %v", actions))
+	createCmd.Flags().String("description", "", "Description of the rule")
+	createCmd.Flags().String("position", "", "Where to add the rule: first, last, a 1-based index, before:<rule> or after:<rule> (default last)")
+	createCmd.Flags().Bool("disabled", false, "Create the rule disabled")
+	_ = createCmd.MarkFlagRequired("expression")
+	WafCmd.AddCommand(createCmd)
+}
+
+func createRule(ctx *executor.Context, _ chan<- string) (*cloudflare.Rule, error) {
+	expression, _ := ctx.Cmd.Flags().GetString("expression")
+	action, _ := ctx.Cmd.Flags().GetString("action")
+	description, _ := ctx.Cmd.Flags().GetString("description")
+	position, _ := ctx.Cmd.Flags().GetString("position")
+	disabled, _ := ctx.Cmd.Flags().GetBool("disabled")
+
+	rule := cloudflare.Rule{Expression: expression, Description: description}
+	if err := applyAction(&rule, action); err != nil {
+		return nil, err
+	}
+	if disabled {
+		enabled := false
+		rule.Enabled = &enabled
+	}
+
+	existing, err := phase.Rules(ctx.Client, executor.Get(ctx, executor.ZoneIDKey))
+	if err != nil {
+		return nil, err
+	}
+	pos, err := cloudflare.ParseRulePosition(existing, position)
+	if err != nil {
+		return nil, err
+	}
+
+	_, created, err := phase.AddRule(ctx.Client, executor.Get(ctx, executor.ZoneIDKey), rule, pos)
+	return created, err
+}
+
+func printCreateRule(ctx *executor.Context) {
+	rb := response.New()
+	if ctx.Error != nil {
+		displayError(rb, "Error creating WAF rule", ctx.Error)
+		return
+	}
+	rule := executor.Get(ctx, ruleKey)
+	rb.AddItem(rule.Title(), ruleContent(*rule)).
+		FooterSuccessf("Created WAF rule %s in %s %s", rule.ID, executor.Get(ctx, executor.ZoneNameKey), ui.Muted(fmt.Sprintf("(took %v)", ctx.Duration))).
+		Display()
+}
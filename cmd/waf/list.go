@@ -0,0 +1,51 @@
+package waf
+
+import (
+	"fmt"
+
+	"dario.lol/cf/internal/executor"
+	"dario.lol/cf/internal/ui"
+	"dario.lol/cf/internal/ui/response"
+	"github.com/spf13/cobra"
+)
+
+var listCmd = &cobra.Command{
+	Use:   "list <zone>",
+	Short: "List WAF custom rules",
+	Args:  cobra.ExactArgs(1),
+	Run: executor.New().
+		WithClient().
+		WithZone().
+		WithNoCache().
+		Step(executor.NewStep(rulesetKey, "Fetching WAF rules").
+			Func(executor.PhaseRules(phase)).
+			CacheKeyFunc(executor.PhaseCacheKey(phase))).
+		Display(printRules).
+		Run(),
+}
+
+func init() {
+	listCmd.Flags().Bool("no-cache", false, "Bypass the cache and fetch directly from the API")
+	WafCmd.AddCommand(listCmd)
+}
+
+func printRules(ctx *executor.Context) {
+	rb := response.New()
+	if ctx.Error != nil {
+		rb.Error("Error fetching WAF rules", ctx.Error).Display()
+		return
+	}
+
+	ruleset := executor.Get(ctx, rulesetKey)
+	zoneName := executor.Get(ctx, executor.ZoneNameKey)
+	if len(ruleset.Rules) == 0 {
+		rb.FooterSuccessf("No WAF custom rules found for %s", zoneName).Display()
+		return
+	}
+
+	for i, rule := range ruleset.Rules {
+		rb.AddItem(fmt.Sprintf("%d. %s", i+1, rule.Title()), ruleContent(rule))
+	}
+
+	rb.FooterSuccessf("Found %d WAF custom rule(s) for %s %s", len(ruleset.Rules), zoneName, ui.Muted(fmt.Sprintf("(took %v)", ctx.Duration))).Display()
+}
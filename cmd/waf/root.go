@@ -0,0 +1,77 @@
+package waf
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"dario.lol/cf/internal/cloudflare"
+	"dario.lol/cf/internal/executor"
+	"dario.lol/cf/internal/ui"
+	"dario.lol/cf/internal/ui/response"
+	"github.com/spf13/cobra"
+)
+
+var phase = cloudflare.ZonePhase{Name: "http_request_firewall_custom", Command: "waf"}
+
+var actions = []string{"block", "managed_challenge", "js_challenge", "challenge", "log", "skip"}
+
+var (
+	rulesetKey = executor.NewKey[*cloudflare.Ruleset]("wafRuleset")
+	ruleKey    = executor.NewKey[*cloudflare.Rule]("wafRule")
+)
+
+var WafCmd = &cobra.Command{
+	Use:   "waf",
+	Short: "Manage WAF custom rules",
+	Long:  "WAF custom rules block or challenge requests matching an expression. They live in the zone's http_request_firewall_custom ruleset and are evaluated in order.",
+}
+
+// applyAction sets the rule's action, adding the parameters skip needs to
+// skip the remaining custom rules.
+func applyAction(rule *cloudflare.Rule, action string) error {
+	if !slices.Contains(actions, action) {
+		return fmt.Errorf("invalid action: %s. valid actions are: %v", action, actions)
+	}
+	rule.Action = action
+	rule.ActionParameters = nil
+	if action == "skip" {
+		rule.ActionParameters = map[string]any{"ruleset": "current"}
+	}
+	return nil
+}
+
+func ruleContent(rule cloudflare.Rule) string {
+	enabled := ui.Text("yes")
+	if !rule.IsEnabled() {
+		enabled = ui.Muted("no")
+	}
+	icb := response.NewItemContent().
+		Add("ID:", ui.Text(rule.ID)).
+		Add("Action:", ui.Text(rule.Action)).
+		Add("Expression:", ui.Text(rule.Expression)).
+		Add("Enabled:", enabled)
+	if updated, err := time.Parse(time.RFC3339Nano, rule.LastUpdated); err == nil {
+		icb.Add("Updated:", ui.Text(updated.Local().Format("2006-01-02 15:04:05")))
+	}
+	return icb.String()
+}
+
+// ruleByID returns the rule with the given ID from an updated ruleset.
+func ruleByID(ruleset *cloudflare.Ruleset, id string) cloudflare.Rule {
+	for _, rule := range ruleset.Rules {
+		if rule.ID == id {
+			return rule
+		}
+	}
+	return cloudflare.Rule{ID: id}
+}
+
+func displayError(rb *response.Builder, title string, err error) {
+	if errors.Is(err, cloudflare.ErrInvalidExpression) {
+		rb.Error("Invalid rule expression", err).Display()
+		return
+	}
+	rb.Error(title, err).Display()
+}
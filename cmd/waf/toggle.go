@@ -0,0 +1,64 @@
+package waf
+
+import (
+	"fmt"
+
+	"dario.lol/cf/internal/cloudflare"
+	"dario.lol/cf/internal/executor"
+	"dario.lol/cf/internal/ui"
+	"dario.lol/cf/internal/ui/response"
+	"github.com/spf13/cobra"
+)
+
+var enableCmd = &cobra.Command{
+	Use:   "enable <zone> <rule>",
+	Short: "Enable a WAF custom rule",
+	Args:  cobra.ExactArgs(2),
+	Run:   toggleRunner(true),
+}
+
+var disableCmd = &cobra.Command{
+	Use:   "disable <zone> <rule>",
+	Short: "Disable a WAF custom rule without deleting it",
+	Args:  cobra.ExactArgs(2),
+	Run:   toggleRunner(false),
+}
+
+func init() {
+	WafCmd.AddCommand(enableCmd)
+	WafCmd.AddCommand(disableCmd)
+}
+
+func toggleRunner(enabled bool) func(*cobra.Command, []string) {
+	return executor.New().
+		WithClient().
+		WithZone().
+		Step(executor.NewStep(ruleKey, "Fetching WAF rules").Func(executor.PhaseRule(phase, rulesetKey))).
+		Step(executor.NewStep(ruleKey, "Updating WAF rule").Func(func(ctx *executor.Context, _ chan<- string) (*cloudflare.Rule, error) {
+			rule := *executor.Get(ctx, ruleKey)
+			rule.Enabled = &enabled
+			updated, err := cloudflare.EditRule(ctx.Client, cloudflare.ZoneScope(executor.Get(ctx, executor.ZoneIDKey)), executor.Get(ctx, rulesetKey).ID, rule, cloudflare.RulePosition{})
+			if err != nil {
+				return nil, err
+			}
+			result := ruleByID(updated, rule.ID)
+			return &result, nil
+		})).
+		Invalidates(executor.PhaseTags(phase)).
+		Display(printToggleRule).
+		Run()
+}
+
+func printToggleRule(ctx *executor.Context) {
+	rb := response.New()
+	if ctx.Error != nil {
+		rb.Error("Error updating WAF rule", ctx.Error).Display()
+		return
+	}
+	rule := executor.Get(ctx, ruleKey)
+	state := "enabled"
+	if !rule.IsEnabled() {
+		state = "disabled"
+	}
+	rb.FooterSuccessf("WAF rule %s is now %s %s", rule.Label(), state, ui.Muted(fmt.Sprintf("(took %v)", ctx.Duration))).Display()
+}
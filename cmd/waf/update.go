@@ -0,0 +1,82 @@
+package waf
+
+import (
+	"fmt"
+
+	"dario.lol/cf/internal/cloudflare"
+	"dario.lol/cf/internal/executor"
+	"dario.lol/cf/internal/ui"
+	"dario.lol/cf/internal/ui/response"
+	"github.com/spf13/cobra"
+)
+
+var updateCmd = &cobra.Command{
+	Use:   "update <zone> <rule>",
+	Short: "Update or move a WAF custom rule by ID or description",
+	Example: `  cf waf update example.com "Block Tor" --action managed_challenge
+  cf waf update example.com 2c0fc9fa937b11eaa1b71c4d701ab86e --position first`,
+	Args: cobra.ExactArgs(2),
+	Run: executor.New().
+		WithClient().
+		WithZone().
+		Step(executor.NewStep(ruleKey, "Fetching WAF rules").Func(executor.PhaseRule(phase, rulesetKey))).
+		Step(executor.NewStep(ruleKey, "Updating WAF rule").Func(updateRule)).
+		Invalidates(executor.PhaseTags(phase)).
+		Display(printUpdateRule).
+		Run(),
+}
+
+func init() {
+	updateCmd.Flags().String("expression", "", "New expression")
+	updateCmd.Flags().String("action", "", fmt.Sprintf("New action: %v", actions))
+	updateCmd.Flags().String("description", "", "New description")
+	updateCmd.Flags().String("position", "", "Move the rule: first, last, a 1-based index, before:<rule> or after:<rule>")
+	updateCmd.MarkFlagsOneRequired("expression", "action", "description", "position")
+	WafCmd.AddCommand(updateCmd)
+}
+
+func updateRule(ctx *executor.Context, _ chan<- string) (*cloudflare.Rule, error) {
+	ruleset := executor.Get(ctx, rulesetKey)
+	rule := *executor.Get(ctx, ruleKey)
+	flags := ctx.Cmd.Flags()
+
+	if flags.Changed("expression") {
+		rule.Expression, _ = flags.GetString("expression")
+	}
+	if flags.Changed("description") {
+		rule.Description, _ = flags.GetString("description")
+	}
+	if flags.Changed("action") {
+		action, _ := flags.GetString("action")
+		if err := applyAction(&rule, action); err != nil {
+			return nil, err
+		}
+	}
+	position, _ := flags.GetString("position")
+	pos, err := cloudflare.ParseRulePosition(ruleset, position)
+	if err != nil {
+		return nil, err
+	}
+	if pos.Before == rule.ID || pos.After == rule.ID {
+		pos = cloudflare.RulePosition{}
+	}
+
+	updated, err := cloudflare.EditRule(ctx.Client, cloudflare.ZoneScope(executor.Get(ctx, executor.ZoneIDKey)), ruleset.ID, rule, pos)
+	if err != nil {
+		return nil, err
+	}
+	result := ruleByID(updated, rule.ID)
+	return &result, nil
+}
+
+func printUpdateRule(ctx *executor.Context) {
+	rb := response.New()
+	if ctx.Error != nil {
+		displayError(rb, "Error updating WAF rule", ctx.Error)
+		return
+	}
+	rule := executor.Get(ctx, ruleKey)
+	rb.AddItem(rule.Title(), ruleContent(*rule)).
+		FooterSuccessf("Updated WAF rule %s in %s %s", rule.ID, executor.Get(ctx, executor.ZoneNameKey), ui.Muted(fmt.Sprintf("(took %v)", ctx.Duration))).
+		Display()
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"dario.lol/cf/internal/config"
 	"dario.lol/cf/internal/executor"
@@ -23,7 +24,8 @@ var whoAmICmd = &cobra.Command{
 		WithNoCache().
 		Step(executor.NewStep(userKey, "Fetching user information").
 			Func(fetchUser).
-			CacheKey("user:whoami")).
+			CacheKey("user:whoami").
+			CacheTTL(time.Hour)).
 		Display(printUserInfo).
 		Run(),
 }
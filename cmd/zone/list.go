@@ -3,6 +3,7 @@ package zone
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"dario.lol/cf/internal/cloudflare"
 	"dario.lol/cf/internal/executor"
@@ -25,7 +26,8 @@ var listCmd = &cobra.Command{
 		WithNoCache().
 		Step(executor.NewStep(zonesKey, "Fetching zones").
 			Func(fetchZones).
-			CacheKey("zones:list")).
+			CacheKey("zones:list").
+			CacheTTL(30 * time.Minute)).
 		Display(printZonesList).
 		Run(),
 }
@@ -7,7 +7,7 @@ import (
 )
 
 func GetID(key string) (string, bool) {
-	val, err := db.Get(db.CacheBucket, []byte(key))
+	val, err := db.Get(db.IDBucket, []byte(key))
 	if err != nil || val == nil {
 		return "", false
 	}
@@ -15,7 +15,7 @@ func GetID(key string) (string, bool) {
 }
 
 func SetID(key, id string) {
-	_ = db.Set(db.CacheBucket, []byte(key), []byte(id))
+	_ = db.Set(db.IDBucket, []byte(key), []byte(id))
 }
 
 func ZoneCacheKey(zoneIdentifier string) string {
@@ -29,3 +29,48 @@ func DNSRecordCacheKey(zoneID, recordName string) string {
 func DNSRecordCacheKeyByID(recordID string) string {
 	return fmt.Sprintf("dns_by_id:%s", recordID)
 }
+
+// RulesCacheKey is the cache key of a command's cached rules of a zone phase.
+// Commands store results under their own executor keys, so each one needs
+// its own entry.
+func RulesCacheKey(zoneID, phase, command string) string {
+	return RulesCacheTag(zoneID, phase) + command
+}
+
+// RulesCacheTag invalidates the cached rules of a zone phase for every
+// command.
+func RulesCacheTag(zoneID, phase string) string {
+	return fmt.Sprintf("zone:%s:rules:%s:", zoneID, phase)
+}
+
+func DeleteID(key string) {
+	_ = db.Set(db.IDBucket, []byte(key), nil)
+}
+
+func KVNamespaceCacheKey(accountID, namespaceIdentifier string) string {
+	return fmt.Sprintf("kv_namespace:%s:%s", accountID, namespaceIdentifier)
+}
+
+func D1DatabaseCacheKey(accountID, databaseIdentifier string) string {
+	return fmt.Sprintf("d1_database:%s:%s", accountID, databaseIdentifier)
+}
+
+func R2BucketCacheKey(accountID, jurisdiction, bucketName string) string {
+	if jurisdiction == "" {
+		jurisdiction = "default"
+	}
+	return fmt.Sprintf("r2_bucket:%s:%s:%s", accountID, jurisdiction, bucketName)
+}
+
+// R2BucketCacheTag prefixes the cache keys of everything stored for a
+// bucket, so deleting the bucket can invalidate them together.
+func R2BucketCacheTag(accountID, jurisdiction, bucketName string) string {
+	if jurisdiction == "" {
+		jurisdiction = "default"
+	}
+	return fmt.Sprintf("r2:bucket:%s:%s:%s:", accountID, jurisdiction, bucketName)
+}
+
+func PagesProjectCacheKey(accountID, projectIdentifier string) string {
+	return fmt.Sprintf("pages_project:%s:%s", accountID, projectIdentifier)
+}
@@ -0,0 +1,121 @@
+package cloudflare
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cloudflare/cloudflare-go/v6"
+)
+
+// List is an account-level rules list, such as a bulk redirect list.
+type List struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Kind        string `json:"kind"`
+	NumItems    int    `json:"num_items"`
+}
+
+// RedirectItem is one entry of a redirect list.
+type RedirectItem struct {
+	Redirect Redirect `json:"redirect"`
+}
+
+type Redirect struct {
+	SourceURL           string `json:"source_url"`
+	TargetURL           string `json:"target_url"`
+	StatusCode          int    `json:"status_code,omitempty"`
+	PreserveQueryString bool   `json:"preserve_query_string,omitempty"`
+	IncludeSubdomains   bool   `json:"include_subdomains,omitempty"`
+	SubpathMatching     bool   `json:"subpath_matching,omitempty"`
+	PreservePathSuffix  bool   `json:"preserve_path_suffix,omitempty"`
+}
+
+type bulkOperation struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+	Error  string `json:"error"`
+}
+
+func listsPath(accountID, format string, args ...any) string {
+	return fmt.Sprintf("accounts/%s/rules/lists", accountID) + fmt.Sprintf(format, args...)
+}
+
+// FindList returns the account's list with the given name, or nil when there
+// is none.
+func FindList(client *cloudflare.Client, accountID, name string) (*List, error) {
+	var env struct {
+		Result []List `json:"result"`
+	}
+	if err := client.Get(context.Background(), listsPath(accountID, ""), nil, &env); err != nil {
+		return nil, err
+	}
+	for _, list := range env.Result {
+		if list.Name == name {
+			return &list, nil
+		}
+	}
+	return nil, nil
+}
+
+func CreateList(client *cloudflare.Client, accountID string, list List) (*List, error) {
+	body := map[string]any{"name": list.Name, "kind": list.Kind}
+	if list.Description != "" {
+		body["description"] = list.Description
+	}
+	var env struct {
+		Result List `json:"result"`
+	}
+	if err := client.Post(context.Background(), listsPath(accountID, ""), body, &env); err != nil {
+		return nil, err
+	}
+	return &env.Result, nil
+}
+
+// ReplaceRedirectItems replaces all items of a redirect list, or appends to
+// it when replace is false, and waits up to timeout for the bulk operation to
+// finish.
+func ReplaceRedirectItems(client *cloudflare.Client, accountID, listID string, items []RedirectItem, replace bool, timeout time.Duration) error {
+	var env struct {
+		Result struct {
+			OperationID string `json:"operation_id"`
+		} `json:"result"`
+	}
+	path := listsPath(accountID, "/%s/items", listID)
+	var err error
+	if replace {
+		err = client.Put(context.Background(), path, items, &env)
+	} else {
+		err = client.Post(context.Background(), path, items, &env)
+	}
+	if err != nil {
+		return err
+	}
+	return waitForBulkOperation(client, accountID, env.Result.OperationID, timeout)
+}
+
+func waitForBulkOperation(client *cloudflare.Client, accountID, operationID string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for delay := 250 * time.Millisecond; ; delay = min(delay*2, 5*time.Second) {
+		var env struct {
+			Result bulkOperation `json:"result"`
+		}
+		if err := client.Get(context.Background(), listsPath(accountID, "/bulk_operations/%s", operationID), nil, &env); err != nil {
+			return err
+		}
+		switch env.Result.Status {
+		case "completed":
+			return nil
+		case "failed":
+			return fmt.Errorf("list operation failed: %s", env.Result.Error)
+		case "pending", "running":
+		default:
+			return fmt.Errorf("list operation %s has unknown status %q", operationID, env.Result.Status)
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("list operation %s did not finish within %s", operationID, timeout)
+		}
+		time.Sleep(delay)
+	}
+}
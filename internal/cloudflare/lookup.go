@@ -2,16 +2,24 @@ package cloudflare
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
 	"regexp"
 	"strings"
 
 	"github.com/cloudflare/cloudflare-go/v6"
+	"github.com/cloudflare/cloudflare-go/v6/d1"
 	"github.com/cloudflare/cloudflare-go/v6/dns"
+	"github.com/cloudflare/cloudflare-go/v6/kv"
+	"github.com/cloudflare/cloudflare-go/v6/pages"
+	"github.com/cloudflare/cloudflare-go/v6/r2"
 	"github.com/cloudflare/cloudflare-go/v6/zones"
 )
 
 var isCloudflareID = regexp.MustCompile(`^[a-f0-9]{32}$`).MatchString
+var isUUID = regexp.MustCompile(`^[a-f0-9]{8}-[a-f0-9]{4}-[a-f0-9]{4}-[a-f0-9]{4}-[a-f0-9]{12}$`).MatchString
 
 func LookupZone(client *cloudflare.Client, zoneIdentifier string) (id string, name string, err error) {
 	if isCloudflareID(zoneIdentifier) {
@@ -93,3 +101,158 @@ func LookupDNSRecordID(client *cloudflare.Client, zoneID, zoneName, recordIdenti
 	id, _, err := LookupDNSRecord(client, zoneID, zoneName, recordIdentifier)
 	return id, err
 }
+
+func LookupKVNamespace(client *cloudflare.Client, accountID, namespaceIdentifier string) (id string, title string, err error) {
+	if isCloudflareID(namespaceIdentifier) {
+		id = namespaceIdentifier
+		if cachedTitle, found := GetID(KVNamespaceCacheKey(accountID, id)); found {
+			return id, cachedTitle, nil
+		}
+		ns, err := client.KV.Namespaces.Get(context.Background(), id, kv.NamespaceGetParams{AccountID: cloudflare.F(accountID)})
+		if err != nil {
+			return "", "", err
+		}
+		title = ns.Title
+	} else {
+		title = namespaceIdentifier
+		if cachedID, found := GetID(KVNamespaceCacheKey(accountID, title)); found {
+			return cachedID, title, nil
+		}
+		pager := client.KV.Namespaces.ListAutoPaging(context.Background(), kv.NamespaceListParams{AccountID: cloudflare.F(accountID)})
+		var matches []kv.Namespace
+		for pager.Next() {
+			if ns := pager.Current(); ns.Title == title {
+				matches = append(matches, ns)
+			}
+		}
+		if err := pager.Err(); err != nil {
+			return "", "", err
+		}
+		if len(matches) == 0 {
+			return "", "", fmt.Errorf("KV namespace %q not found", title)
+		}
+		if len(matches) > 1 {
+			msg := fmt.Sprintf("multiple KV namespaces found with title %q:", title)
+			for _, ns := range matches {
+				msg += fmt.Sprintf("\n - %s (%s)", ns.Title, ns.ID)
+			}
+			return "", "", fmt.Errorf("%s\nplease specify the namespace ID", msg)
+		}
+		id = matches[0].ID
+	}
+
+	SetID(KVNamespaceCacheKey(accountID, title), id)
+	SetID(KVNamespaceCacheKey(accountID, id), title)
+	return id, title, nil
+}
+
+func LookupD1Database(client *cloudflare.Client, accountID, databaseIdentifier string) (id string, name string, err error) {
+	if isUUID(databaseIdentifier) {
+		id = databaseIdentifier
+		if cachedName, found := GetID(D1DatabaseCacheKey(accountID, id)); found {
+			return id, cachedName, nil
+		}
+		database, err := client.D1.Database.Get(context.Background(), id, d1.DatabaseGetParams{AccountID: cloudflare.F(accountID)})
+		if err != nil {
+			return "", "", err
+		}
+		name = database.Name
+	} else {
+		name = databaseIdentifier
+		if cachedID, found := GetID(D1DatabaseCacheKey(accountID, name)); found {
+			return cachedID, name, nil
+		}
+		pager := client.D1.Database.ListAutoPaging(context.Background(), d1.DatabaseListParams{
+			AccountID: cloudflare.F(accountID),
+			Name:      cloudflare.F(name),
+		})
+		var matches []d1.DatabaseListResponse
+		for pager.Next() {
+			if database := pager.Current(); database.Name == name {
+				matches = append(matches, database)
+			}
+		}
+		if err := pager.Err(); err != nil {
+			return "", "", err
+		}
+		if len(matches) == 0 {
+			return "", "", fmt.Errorf("D1 database %q not found", name)
+		}
+		if len(matches) > 1 {
+			msg := fmt.Sprintf("multiple D1 databases found with name %q:", name)
+			for _, database := range matches {
+				msg += fmt.Sprintf("\n - %s (%s)", database.Name, database.UUID)
+			}
+			return "", "", fmt.Errorf("%s\nplease specify the database ID", msg)
+		}
+		id = matches[0].UUID
+	}
+
+	SetID(D1DatabaseCacheKey(accountID, name), id)
+	SetID(D1DatabaseCacheKey(accountID, id), name)
+	return id, name, nil
+}
+
+// LookupR2Bucket verifies that a bucket exists. Bucket names are unique per
+// account and jurisdiction, so the name doubles as the identifier and only
+// existence is cached.
+func LookupR2Bucket(client *cloudflare.Client, accountID, bucketName, jurisdiction string) (name string, err error) {
+	if _, found := GetID(R2BucketCacheKey(accountID, jurisdiction, bucketName)); found {
+		return bucketName, nil
+	}
+	params := r2.BucketGetParams{AccountID: cloudflare.F(accountID)}
+	if jurisdiction != "" {
+		params.Jurisdiction = cloudflare.F(r2.BucketGetParamsCfR2Jurisdiction(jurisdiction))
+	}
+	bucket, err := client.R2.Buckets.Get(context.Background(), bucketName, params)
+	var apiErr *cloudflare.Error
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+		return "", fmt.Errorf("R2 bucket %q not found", bucketName)
+	}
+	if err != nil {
+		return "", err
+	}
+	SetID(R2BucketCacheKey(accountID, jurisdiction, bucket.Name), bucket.Name)
+	return bucket.Name, nil
+}
+
+func LookupPagesProject(client *cloudflare.Client, accountID, projectIdentifier string) (id string, name string, err error) {
+	if isUUID(projectIdentifier) {
+		id = projectIdentifier
+		if cachedName, found := GetID(PagesProjectCacheKey(accountID, id)); found {
+			return id, cachedName, nil
+		}
+		pager := client.Pages.Projects.ListAutoPaging(context.Background(), pages.ProjectListParams{AccountID: cloudflare.F(accountID)})
+		for pager.Next() {
+			var project struct {
+				ID   string `json:"id"`
+				Name string `json:"name"`
+			}
+			if err := json.Unmarshal([]byte(pager.Current().JSON.RawJSON()), &project); err == nil && project.ID == id {
+				name = project.Name
+				break
+			}
+		}
+		if err := pager.Err(); err != nil {
+			return "", "", err
+		}
+		if name == "" {
+			return "", "", fmt.Errorf("Pages project %q not found", id)
+		}
+	} else {
+		name = projectIdentifier
+		if cachedID, found := GetID(PagesProjectCacheKey(accountID, name)); found {
+			return cachedID, name, nil
+		}
+		project, err := client.Pages.Projects.Get(context.Background(), name, pages.ProjectGetParams{AccountID: cloudflare.F(accountID)})
+		if err != nil {
+			return "", "", fmt.Errorf("Pages project %q not found: %w", name, err)
+		}
+		id = project.ID
+		name = project.Name
+	}
+
+	SetID(PagesProjectCacheKey(accountID, name), id)
+	SetID(PagesProjectCacheKey(accountID, id), name)
+	return id, name, nil
+}
@@ -0,0 +1,37 @@
+package cloudflare
+
+import "github.com/cloudflare/cloudflare-go/v6"
+
+// ZonePhase is a zone ruleset phase as managed by one command. Command keeps
+// that command's cached rules apart from other commands listing the phase.
+type ZonePhase struct {
+	Name    string
+	Command string
+}
+
+func (p ZonePhase) CacheKey(zoneID string) string {
+	return RulesCacheKey(zoneID, p.Name, p.Command)
+}
+
+// CacheTag invalidates the phase's cached rules for every command.
+func (p ZonePhase) CacheTag(zoneID string) string {
+	return RulesCacheTag(zoneID, p.Name)
+}
+
+func (p ZonePhase) Rules(client *cloudflare.Client, zoneID string) (*Ruleset, error) {
+	return GetPhaseEntrypoint(client, ZoneScope(zoneID), p.Name)
+}
+
+// FindRule fetches the phase's rules and resolves a rule with FindRule.
+func (p ZonePhase) FindRule(client *cloudflare.Client, zoneID, identifier string) (*Ruleset, *Rule, error) {
+	ruleset, err := p.Rules(client, zoneID)
+	if err != nil {
+		return nil, nil, err
+	}
+	rule, err := FindRule(ruleset, identifier)
+	return ruleset, rule, err
+}
+
+func (p ZonePhase) AddRule(client *cloudflare.Client, zoneID string, rule Rule, position RulePosition) (*Ruleset, *Rule, error) {
+	return AddPhaseRule(client, ZoneScope(zoneID), p.Name, rule, position)
+}
@@ -0,0 +1,87 @@
+package cloudflare
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"dario.lol/cf/internal/config"
+	"dario.lol/cf/internal/db"
+	"dario.lol/cf/internal/s3"
+	"github.com/cloudflare/cloudflare-go/v6"
+	"github.com/cloudflare/cloudflare-go/v6/accounts"
+)
+
+// R2Credentials returns S3 credentials for the account. CF_R2_ACCESS_KEY_ID
+// and CF_R2_SECRET_ACCESS_KEY take precedence; otherwise they are derived from
+// the configured API token, whose ID is the access key and whose SHA-256 hash
+// is the secret.
+func R2Credentials(client *cloudflare.Client, accountID string) (string, string, error) {
+	if keyID, secret := os.Getenv("CF_R2_ACCESS_KEY_ID"), os.Getenv("CF_R2_SECRET_ACCESS_KEY"); keyID != "" && secret != "" {
+		return keyID, secret, nil
+	}
+
+	token := string(config.Cfg.APIToken)
+	if token == "" {
+		return "", "", errors.New("R2 object access requires an API token login or CF_R2_ACCESS_KEY_ID and CF_R2_SECRET_ACCESS_KEY")
+	}
+	sum := sha256.Sum256([]byte(token))
+	secret := hex.EncodeToString(sum[:])
+
+	cacheKey := "r2_token:" + secret[:16]
+	if tokenID, ok := GetID(cacheKey); ok {
+		return tokenID, secret, nil
+	}
+
+	var tokenID string
+	if res, err := client.User.Tokens.Verify(context.Background()); err == nil {
+		tokenID = res.ID
+	} else {
+		res, accErr := client.Accounts.Tokens.Verify(context.Background(), accounts.TokenVerifyParams{
+			AccountID: cloudflare.F(accountID),
+		})
+		if accErr != nil {
+			return "", "", fmt.Errorf("error verifying API token: %w", accErr)
+		}
+		tokenID = res.ID
+	}
+
+	SetID(cacheKey, tokenID)
+	return tokenID, secret, nil
+}
+
+// R2Endpoint returns the S3 endpoint for the account, honouring CF_R2_ENDPOINT
+// so commands can be pointed at a local S3-compatible server.
+func R2Endpoint(accountID, jurisdiction string) string {
+	if endpoint := os.Getenv("CF_R2_ENDPOINT"); endpoint != "" {
+		return endpoint
+	}
+	switch strings.ToLower(jurisdiction) {
+	case "", "default":
+		return fmt.Sprintf("https://%s.r2.cloudflarestorage.com", accountID)
+	default:
+		return fmt.Sprintf("https://%s.%s.r2.cloudflarestorage.com", accountID, strings.ToLower(jurisdiction))
+	}
+}
+
+func NewR2Client(client *cloudflare.Client, accountID, jurisdiction string) (*s3.Client, error) {
+	keyID, secret, err := R2Credentials(client, accountID)
+	if err != nil {
+		return nil, err
+	}
+	return s3.New(R2Endpoint(accountID, jurisdiction), keyID, secret), nil
+}
+
+type uploadResumeStore struct{}
+
+func (uploadResumeStore) Get(key string) (string, bool) { return db.GetUpload(key) }
+func (uploadResumeStore) Set(key, uploadID string)      { _ = db.SetUpload(key, uploadID) }
+func (uploadResumeStore) Delete(key string)             { _ = db.DeleteUpload(key) }
+
+// R2ResumeStore records unfinished multipart uploads in the local database
+// so a failed upload can be resumed by a later run within db.MaxUploadAge.
+var R2ResumeStore s3.ResumeStore = uploadResumeStore{}
@@ -0,0 +1,358 @@
+package cloudflare
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/cloudflare/cloudflare-go/v6"
+)
+
+// Ruleset is a Rulesets API ruleset. Rules are decoded generically rather
+// than into the SDK's per-action unions so every phase shares one code path.
+type Ruleset struct {
+	ID          string `json:"id,omitempty" yaml:"id,omitempty"`
+	Name        string `json:"name,omitempty" yaml:"name,omitempty"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	Kind        string `json:"kind,omitempty" yaml:"kind,omitempty"`
+	Phase       string `json:"phase,omitempty" yaml:"phase,omitempty"`
+	Version     string `json:"version,omitempty" yaml:"version,omitempty"`
+	LastUpdated string `json:"last_updated,omitempty" yaml:"last_updated,omitempty"`
+	Rules       []Rule `json:"rules" yaml:"rules"`
+}
+
+type Rule struct {
+	ID                     string         `json:"id,omitempty" yaml:"id,omitempty"`
+	Ref                    string         `json:"ref,omitempty" yaml:"ref,omitempty"`
+	Description            string         `json:"description,omitempty" yaml:"description,omitempty"`
+	Expression             string         `json:"expression" yaml:"expression"`
+	Action                 string         `json:"action" yaml:"action"`
+	ActionParameters       map[string]any `json:"action_parameters,omitempty" yaml:"action_parameters,omitempty"`
+	Enabled                *bool          `json:"enabled,omitempty" yaml:"enabled,omitempty"`
+	Ratelimit              *Ratelimit     `json:"ratelimit,omitempty" yaml:"ratelimit,omitempty"`
+	Logging                map[string]any `json:"logging,omitempty" yaml:"logging,omitempty"`
+	ExposedCredentialCheck map[string]any `json:"exposed_credential_check,omitempty" yaml:"exposed_credential_check,omitempty"`
+	Version                string         `json:"version,omitempty" yaml:"version,omitempty"`
+	LastUpdated            string         `json:"last_updated,omitempty" yaml:"last_updated,omitempty"`
+}
+
+// Label names the rule by its description, falling back to its ID.
+func (r Rule) Label() string {
+	if r.Description != "" {
+		return fmt.Sprintf("%q", r.Description)
+	}
+	return r.ID
+}
+
+// Title names the rule in listings by its description, falling back to its
+// ID and, for rules not created yet, its expression.
+func (r Rule) Title() string {
+	if r.Description != "" {
+		return r.Description
+	}
+	if r.ID == "" {
+		return r.Expression
+	}
+	return "Rule " + r.ID
+}
+
+// IsEnabled reports whether the rule is enabled, which the API assumes when
+// the field is omitted.
+func (r Rule) IsEnabled() bool {
+	return r.Enabled == nil || *r.Enabled
+}
+
+type Ratelimit struct {
+	Characteristics         []string `json:"characteristics" yaml:"characteristics"`
+	Period                  int      `json:"period" yaml:"period"`
+	RequestsPerPeriod       int      `json:"requests_per_period,omitempty" yaml:"requests_per_period,omitempty"`
+	ScorePerPeriod          int      `json:"score_per_period,omitempty" yaml:"score_per_period,omitempty"`
+	ScoreResponseHeaderName string   `json:"score_response_header_name,omitempty" yaml:"score_response_header_name,omitempty"`
+	MitigationTimeout       int      `json:"mitigation_timeout,omitempty" yaml:"mitigation_timeout,omitempty"`
+	CountingExpression      string   `json:"counting_expression,omitempty" yaml:"counting_expression,omitempty"`
+	RequestsToOrigin        bool     `json:"requests_to_origin,omitempty" yaml:"requests_to_origin,omitempty"`
+}
+
+// RulePosition places a new or moved rule relative to the others. The zero
+// value appends the rule.
+type RulePosition struct {
+	Before string `json:"before,omitempty"`
+	After  string `json:"after,omitempty"`
+	Index  int    `json:"index,omitempty"`
+}
+
+// RulesetScope is the zone or account owning a ruleset.
+type RulesetScope struct {
+	kind string
+	id   string
+}
+
+func ZoneScope(zoneID string) RulesetScope {
+	return RulesetScope{kind: "zones", id: zoneID}
+}
+
+func AccountScope(accountID string) RulesetScope {
+	return RulesetScope{kind: "accounts", id: accountID}
+}
+
+// rulesetKind is the kind of the phase entrypoints the scope owns.
+func (s RulesetScope) rulesetKind() string {
+	if s.kind == "zones" {
+		return "zone"
+	}
+	return "root"
+}
+
+func (s RulesetScope) path(format string, args ...any) string {
+	return fmt.Sprintf("%s/%s/rulesets/", s.kind, s.id) + fmt.Sprintf(format, args...)
+}
+
+type rulesetEnvelope struct {
+	Result Ruleset `json:"result"`
+}
+
+// GetPhaseEntrypoint returns the entrypoint ruleset of a phase. A phase
+// without an entrypoint yields an empty ruleset with no ID.
+func GetPhaseEntrypoint(client *cloudflare.Client, scope RulesetScope, phase string) (*Ruleset, error) {
+	var env rulesetEnvelope
+	err := client.Get(context.Background(), scope.path("phases/%s/entrypoint", phase), nil, &env)
+	var apiErr *cloudflare.Error
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+		return &Ruleset{Kind: scope.rulesetKind(), Phase: phase}, nil
+	}
+	if err != nil {
+		return nil, RulesetError(err)
+	}
+	return &env.Result, nil
+}
+
+// UpdatePhaseEntrypoint replaces all rules of a phase entrypoint, creating it
+// when missing.
+func UpdatePhaseEntrypoint(client *cloudflare.Client, scope RulesetScope, phase string, ruleset Ruleset) (*Ruleset, error) {
+	rules := make([]Rule, len(ruleset.Rules))
+	for i, rule := range ruleset.Rules {
+		rule.Version, rule.LastUpdated = "", ""
+		rules[i] = rule
+	}
+	body := map[string]any{"rules": rules}
+	if ruleset.Description != "" {
+		body["description"] = ruleset.Description
+	}
+	var env rulesetEnvelope
+	if err := client.Put(context.Background(), scope.path("phases/%s/entrypoint", phase), body, &env); err != nil {
+		return nil, RulesetError(err)
+	}
+	return &env.Result, nil
+}
+
+// AddPhaseRule adds a rule to a phase entrypoint, creating the entrypoint
+// when the phase has none yet. It returns the updated ruleset and the rule as
+// created, with its ID.
+func AddPhaseRule(client *cloudflare.Client, scope RulesetScope, phase string, rule Rule, position RulePosition) (*Ruleset, *Rule, error) {
+	entrypoint, err := GetPhaseEntrypoint(client, scope, phase)
+	if err != nil {
+		return nil, nil, err
+	}
+	if entrypoint.ID == "" {
+		ruleset, err := UpdatePhaseEntrypoint(client, scope, phase, Ruleset{Rules: []Rule{rule}})
+		if err != nil {
+			return nil, nil, err
+		}
+		return ruleset, addedRule(entrypoint, ruleset, rule), nil
+	}
+
+	body := map[string]any{
+		"expression": rule.Expression,
+		"action":     rule.Action,
+	}
+	addRuleFields(body, rule)
+	if position != (RulePosition{}) {
+		body["position"] = position
+	}
+	var env rulesetEnvelope
+	if err := client.Post(context.Background(), scope.path("%s/rules", entrypoint.ID), body, &env); err != nil {
+		return nil, nil, RulesetError(err)
+	}
+	return &env.Result, addedRule(entrypoint, &env.Result, rule), nil
+}
+
+// addedRule finds the rule that is in updated but not in before, falling
+// back to the rule as sent.
+func addedRule(before, updated *Ruleset, rule Rule) *Rule {
+	for i, r := range updated.Rules {
+		if !slices.ContainsFunc(before.Rules, func(b Rule) bool { return b.ID == r.ID }) {
+			return &updated.Rules[i]
+		}
+	}
+	return &rule
+}
+
+// EditRule replaces the definition of an existing rule and optionally moves
+// it, so callers pass the full rule with their changes applied.
+func EditRule(client *cloudflare.Client, scope RulesetScope, rulesetID string, rule Rule, position RulePosition) (*Ruleset, error) {
+	body := map[string]any{
+		"expression": rule.Expression,
+		"action":     rule.Action,
+	}
+	addRuleFields(body, rule)
+	if position != (RulePosition{}) {
+		body["position"] = position
+	}
+	var env rulesetEnvelope
+	if err := client.Patch(context.Background(), scope.path("%s/rules/%s", rulesetID, rule.ID), body, &env); err != nil {
+		return nil, RulesetError(err)
+	}
+	return &env.Result, nil
+}
+
+func DeleteRule(client *cloudflare.Client, scope RulesetScope, rulesetID, ruleID string) (*Ruleset, error) {
+	var env rulesetEnvelope
+	if err := client.Delete(context.Background(), scope.path("%s/rules/%s", rulesetID, ruleID), nil, &env); err != nil {
+		return nil, RulesetError(err)
+	}
+	return &env.Result, nil
+}
+
+func addRuleFields(body map[string]any, rule Rule) {
+	if rule.Ref != "" {
+		body["ref"] = rule.Ref
+	}
+	if rule.Description != "" {
+		body["description"] = rule.Description
+	}
+	if rule.ActionParameters != nil {
+		body["action_parameters"] = rule.ActionParameters
+	}
+	if rule.Enabled != nil {
+		body["enabled"] = *rule.Enabled
+	}
+	if rule.Ratelimit != nil {
+		body["ratelimit"] = rule.Ratelimit
+	}
+	if rule.Logging != nil {
+		body["logging"] = rule.Logging
+	}
+	if rule.ExposedCredentialCheck != nil {
+		body["exposed_credential_check"] = rule.ExposedCredentialCheck
+	}
+}
+
+// FindRule looks a rule up by ID, ref or description.
+func FindRule(ruleset *Ruleset, identifier string) (*Rule, error) {
+	var matches []*Rule
+	for i := range ruleset.Rules {
+		rule := &ruleset.Rules[i]
+		if rule.ID == identifier || rule.Ref == identifier {
+			return rule, nil
+		}
+		if strings.EqualFold(rule.Description, identifier) {
+			matches = append(matches, rule)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("rule %q not found", identifier)
+	case 1:
+		return matches[0], nil
+	default:
+		return nil, fmt.Errorf("%d rules are described as %q, use the rule ID instead", len(matches), identifier)
+	}
+}
+
+// ParseRulePosition parses a --position value: first, last, a 1-based index,
+// before:<rule> or after:<rule>, where rules are looked up with FindRule.
+func ParseRulePosition(ruleset *Ruleset, value string) (RulePosition, error) {
+	switch {
+	case value == "":
+		return RulePosition{}, nil
+	case value == "first":
+		return RulePosition{Index: 1}, nil
+	case value == "last":
+		if len(ruleset.Rules) == 0 {
+			return RulePosition{}, nil
+		}
+		return RulePosition{After: ruleset.Rules[len(ruleset.Rules)-1].ID}, nil
+	case strings.HasPrefix(value, "before:"), strings.HasPrefix(value, "after:"):
+		where, identifier, _ := strings.Cut(value, ":")
+		rule, err := FindRule(ruleset, identifier)
+		if err != nil {
+			return RulePosition{}, err
+		}
+		if where == "before" {
+			return RulePosition{Before: rule.ID}, nil
+		}
+		return RulePosition{After: rule.ID}, nil
+	}
+	index, err := strconv.Atoi(value)
+	if err != nil || index < 1 || index > len(ruleset.Rules)+1 {
+		return RulePosition{}, fmt.Errorf("invalid --position %q, expected first, last, an index from 1 to %d, before:<rule> or after:<rule>", value, len(ruleset.Rules)+1)
+	}
+	return RulePosition{Index: index}, nil
+}
+
+// ErrInvalidExpression matches errors returned for a malformed rule
+// expression.
+var ErrInvalidExpression = errors.New("invalid rule expression")
+
+type rulesetError struct {
+	msg        string
+	expression bool
+}
+
+func (e *rulesetError) Error() string {
+	return e.msg
+}
+
+func (e *rulesetError) Is(target error) bool {
+	return target == ErrInvalidExpression && e.expression
+}
+
+// RulesetError turns Rulesets API errors into a readable message, naming
+// the offending field, e.g. "rules[0].expression: filter parsing error".
+func RulesetError(err error) error {
+	var apiErr *cloudflare.Error
+	if !errors.As(err, &apiErr) || len(apiErr.Errors) == 0 {
+		return err
+	}
+	result := &rulesetError{}
+	var lines []string
+	for _, e := range apiErr.Errors {
+		msg := e.Message
+		if pointer := e.Source.Pointer; pointer != "" {
+			msg = pointerPath(pointer) + ": " + msg
+			if strings.HasSuffix(pointer, "/expression") {
+				result.expression = true
+			}
+		}
+		if strings.Contains(e.Message, "filter parsing error") {
+			result.expression = true
+		}
+		lines = append(lines, msg)
+	}
+	result.msg = strings.Join(lines, "\n")
+	return result
+}
+
+// pointerPath renders a JSON pointer such as /rules/0/expression as
+// rules[0].expression.
+func pointerPath(pointer string) string {
+	var sb strings.Builder
+	for _, part := range strings.Split(strings.Trim(pointer, "/"), "/") {
+		if part == "" {
+			continue
+		}
+		if part[0] >= '0' && part[0] <= '9' {
+			sb.WriteString("[" + part + "]")
+			continue
+		}
+		if sb.Len() > 0 {
+			sb.WriteString(".")
+		}
+		sb.WriteString(part)
+	}
+	return sb.String()
+}
@@ -3,17 +3,20 @@ package config
 import (
 	"errors"
 	"os"
+	"time"
 
 	"dario.lol/cf/internal/db"
 )
 
 type Config struct {
-	APIToken      EncryptedString `mapstructure:"api_token"`
-	APIKey        EncryptedString `mapstructure:"api_key"`
-	APIEmail      string          `mapstructure:"api_email"`
-	AccountID     string          `mapstructure:"account_id"`
-	KVNamespaceID string          `mapstructure:"kv_namespace_id"`
-	Caching       bool            `mapstructure:"caching"`
+	APIToken             EncryptedString `mapstructure:"api_token"`
+	APIKey               EncryptedString `mapstructure:"api_key"`
+	APIEmail             string          `mapstructure:"api_email"`
+	AccountID            string          `mapstructure:"account_id"`
+	KVNamespaceID        string          `mapstructure:"kv_namespace_id"`
+	Caching              bool            `mapstructure:"caching"`
+	CacheTTL             time.Duration   `mapstructure:"cache_ttl"`
+	StaleWhileRevalidate bool            `mapstructure:"stale_while_revalidate"`
 }
 
 var ErrNotLoggedIn = errors.New("you are not logged in. Please use 'cf login'")
@@ -62,6 +65,16 @@ func LoadConfig() error {
 		newCfg.Caching = true
 	}
 
+	cacheTTL, err := db.Get(db.ConfigBucket, []byte("cache_ttl"))
+	if err == nil && len(cacheTTL) > 0 {
+		newCfg.CacheTTL, _ = time.ParseDuration(string(cacheTTL))
+	}
+
+	swr, err := db.Get(db.ConfigBucket, []byte("stale_while_revalidate"))
+	if err == nil {
+		newCfg.StaleWhileRevalidate = string(swr) == "true"
+	}
+
 	Cfg = newCfg
 
 	if token := os.Getenv("CF_API_TOKEN"); token != "" {
@@ -114,6 +127,8 @@ func SaveConfig() error {
 		if err := db.Set(db.ConfigBucket, []byte("kv_namespace_id"), []byte(Cfg.KVNamespaceID)); err != nil {
 			return err
 		}
+	} else if err := db.Set(db.ConfigBucket, []byte("kv_namespace_id"), nil); err != nil {
+		return err
 	}
 
 	cachingStr := "false"
@@ -124,5 +139,21 @@ func SaveConfig() error {
 		return err
 	}
 
+	if Cfg.CacheTTL > 0 {
+		if err := db.Set(db.ConfigBucket, []byte("cache_ttl"), []byte(Cfg.CacheTTL.String())); err != nil {
+			return err
+		}
+	} else if err := db.Set(db.ConfigBucket, []byte("cache_ttl"), nil); err != nil {
+		return err
+	}
+
+	swrStr := "false"
+	if Cfg.StaleWhileRevalidate {
+		swrStr = "true"
+	}
+	if err := db.Set(db.ConfigBucket, []byte("stale_while_revalidate"), []byte(swrStr)); err != nil {
+		return err
+	}
+
 	return nil
 }
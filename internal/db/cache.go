@@ -0,0 +1,269 @@
+package db
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+	"time"
+
+	"go.etcd.io/bbolt"
+)
+
+const (
+	// MaxCacheAge is the age after which a cached response is of no use in
+	// any mode, unless its own TTL is longer.
+	MaxCacheAge = 24 * time.Hour
+	// PruneInterval is how often Open prunes the cache automatically.
+	PruneInterval = 6 * time.Hour
+)
+
+var (
+	lastPrunedKey  = []byte("cache_last_pruned")
+	idsMigratedKey = []byte("ids_migrated")
+)
+
+// CacheEntry describes a cached response and the tags pointing to it.
+type CacheEntry struct {
+	Key       string
+	Tags      []string
+	Timestamp time.Time
+	TTL       time.Duration
+	Size      int
+}
+
+// Age returns how long ago the entry was stored.
+func (e CacheEntry) Age() time.Duration {
+	return time.Since(e.Timestamp)
+}
+
+// PruneResult reports what PruneCache removed.
+type PruneResult struct {
+	Entries int
+	Bytes   int
+	Tags    int
+	// Uploads counts forgotten multipart uploads older than MaxUploadAge.
+	Uploads int
+}
+
+// decodeEntry reports false for values that are not cached responses, such
+// as ID mappings written before they moved to IDBucket.
+func decodeEntry(key, value []byte) (CacheEntry, bool) {
+	var stored struct {
+		Timestamp time.Time     `json:"timestamp"`
+		TTL       time.Duration `json:"ttl"`
+	}
+	if err := json.Unmarshal(value, &stored); err != nil || stored.Timestamp.IsZero() {
+		return CacheEntry{}, false
+	}
+	return CacheEntry{
+		Key:       string(key),
+		Timestamp: stored.Timestamp,
+		TTL:       stored.TTL,
+		Size:      len(key) + len(value),
+	}, true
+}
+
+// migrateIDs moves ID mappings that older versions stored in CacheBucket to
+// IDBucket, once.
+func migrateIDs(tx *bbolt.Tx) error {
+	config := tx.Bucket(ConfigBucket)
+	if config.Get(idsMigratedKey) != nil {
+		return nil
+	}
+	cacheBucket := tx.Bucket(CacheBucket)
+	idBucket := tx.Bucket(IDBucket)
+	var legacy [][]byte
+	err := cacheBucket.ForEach(func(k, v []byte) error {
+		if _, ok := decodeEntry(k, v); !ok {
+			legacy = append(legacy, append([]byte(nil), k...))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, k := range legacy {
+		if idBucket.Get(k) == nil {
+			if err := idBucket.Put(k, append([]byte(nil), cacheBucket.Get(k)...)); err != nil {
+				return err
+			}
+		}
+		if err := cacheBucket.Delete(k); err != nil {
+			return err
+		}
+	}
+	return config.Put(idsMigratedKey, []byte("1"))
+}
+
+// CacheEntries returns all cached responses with their tags.
+func CacheEntries() ([]CacheEntry, error) {
+	database, err := Open()
+	if err != nil {
+		return nil, err
+	}
+	var entries []CacheEntry
+	err = database.View(func(tx *bbolt.Tx) error {
+		index := make(map[string]int)
+		err := tx.Bucket(CacheBucket).ForEach(func(k, v []byte) error {
+			entry, ok := decodeEntry(k, v)
+			if !ok {
+				return nil
+			}
+			index[string(k)] = len(entries)
+			entries = append(entries, entry)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		return tx.Bucket(CacheTagsBucket).ForEach(func(tag, v []byte) error {
+			var keys []string
+			if err := json.Unmarshal(v, &keys); err != nil {
+				return nil
+			}
+			for _, key := range keys {
+				if i, ok := index[key]; ok && !slices.Contains(entries[i].Tags, string(tag)) {
+					entries[i].Tags = append(entries[i].Tags, string(tag))
+				}
+			}
+			return nil
+		})
+	})
+	return entries, err
+}
+
+// ClearCache deletes every cached response and tag.
+func ClearCache() (PruneResult, error) {
+	return PruneCache(func(CacheEntry) bool { return true })
+}
+
+// PruneCache deletes the cached responses for which expired returns true,
+// drops tag references to missing responses and deduplicates tag lists. It
+// also forgets multipart uploads too old to resume.
+func PruneCache(expired func(CacheEntry) bool) (PruneResult, error) {
+	database, err := Open()
+	if err != nil {
+		return PruneResult{}, err
+	}
+	var result PruneResult
+	err = database.Update(func(tx *bbolt.Tx) error {
+		result, err = pruneCache(tx, expired)
+		if err != nil {
+			return err
+		}
+		result.Uploads, err = pruneUploads(tx)
+		return err
+	})
+	return result, err
+}
+
+func pruneCache(tx *bbolt.Tx, expired func(CacheEntry) bool) (PruneResult, error) {
+	var result PruneResult
+	cacheBucket := tx.Bucket(CacheBucket)
+	tagsBucket := tx.Bucket(CacheTagsBucket)
+
+	var stale [][]byte
+	err := cacheBucket.ForEach(func(k, v []byte) error {
+		entry, ok := decodeEntry(k, v)
+		if ok && expired(entry) {
+			stale = append(stale, append([]byte(nil), k...))
+			result.Entries++
+			result.Bytes += entry.Size
+		}
+		return nil
+	})
+	if err != nil {
+		return result, err
+	}
+	for _, k := range stale {
+		if err := cacheBucket.Delete(k); err != nil {
+			return result, err
+		}
+	}
+
+	updates := make(map[string][]byte)
+	err = tagsBucket.ForEach(func(tag, v []byte) error {
+		var keys []string
+		_ = json.Unmarshal(v, &keys)
+		var live []string
+		for _, key := range keys {
+			if cacheBucket.Get([]byte(key)) != nil && !slices.Contains(live, key) {
+				live = append(live, key)
+			}
+		}
+		if len(live) == 0 {
+			updates[string(tag)] = nil
+			result.Tags++
+			return nil
+		}
+		if len(live) != len(keys) {
+			data, err := json.Marshal(live)
+			if err != nil {
+				return err
+			}
+			updates[string(tag)] = data
+		}
+		return nil
+	})
+	if err != nil {
+		return result, err
+	}
+	for tag, data := range updates {
+		if data == nil {
+			err = tagsBucket.Delete([]byte(tag))
+		} else {
+			err = tagsBucket.Put([]byte(tag), data)
+		}
+		if err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+// autoPrune removes responses past both their TTL and MaxCacheAge, and
+// uploads past MaxUploadAge, at most once per PruneInterval.
+func autoPrune(database *bbolt.DB) error {
+	return database.Update(func(tx *bbolt.Tx) error {
+		config := tx.Bucket(ConfigBucket)
+		var last time.Time
+		if v := config.Get(lastPrunedKey); v != nil {
+			_ = last.UnmarshalText(v)
+		}
+		if time.Since(last) < PruneInterval {
+			return nil
+		}
+		_, err := pruneCache(tx, func(e CacheEntry) bool {
+			return e.Age() > max(e.TTL, MaxCacheAge)
+		})
+		if err != nil {
+			return err
+		}
+		if _, err := pruneUploads(tx); err != nil {
+			return err
+		}
+		now, err := time.Now().MarshalText()
+		if err != nil {
+			return err
+		}
+		return config.Put(lastPrunedKey, now)
+	})
+}
+
+// InvalidateTagPrefix deletes the cached responses tagged with any tag
+// starting with prefix and returns how many were removed.
+func InvalidateTagPrefix(prefix string) (PruneResult, error) {
+	entries, err := CacheEntries()
+	if err != nil {
+		return PruneResult{}, err
+	}
+	selected := make(map[string]bool)
+	for _, entry := range entries {
+		for _, tag := range entry.Tags {
+			if strings.HasPrefix(tag, prefix) {
+				selected[entry.Key] = true
+			}
+		}
+	}
+	return PruneCache(func(e CacheEntry) bool { return selected[e.Key] })
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"slices"
 	"sync"
 
 	"go.etcd.io/bbolt"
@@ -19,6 +20,8 @@ var (
 	ConfigBucket    = []byte("config")
 	CacheBucket     = []byte("cache")
 	CacheTagsBucket = []byte("cache_tags")
+	IDBucket        = []byte("ids")
+	UploadsBucket   = []byte("uploads")
 )
 
 func Open() (*bbolt.DB, error) {
@@ -49,8 +52,22 @@ func Open() (*bbolt.DB, error) {
 				return err
 			}
 			_, err = tx.CreateBucketIfNotExists(CacheTagsBucket)
-			return err
+			if err != nil {
+				return err
+			}
+			_, err = tx.CreateBucketIfNotExists(IDBucket)
+			if err != nil {
+				return err
+			}
+			_, err = tx.CreateBucketIfNotExists(UploadsBucket)
+			if err != nil {
+				return err
+			}
+			return migrateIDs(tx)
 		})
+		if err == nil {
+			_ = autoPrune(db)
+		}
 	})
 	return db, err
 }
@@ -100,6 +117,9 @@ func AddTagsToKey(key string, tags []string) error {
 					return err
 				}
 			}
+			if slices.Contains(keys, key) {
+				continue
+			}
 			keys = append(keys, key)
 			newKeysBytes, err := json.Marshal(keys)
 			if err != nil {
@@ -0,0 +1,66 @@
+package db
+
+import (
+	"encoding/json"
+	"time"
+
+	"go.etcd.io/bbolt"
+)
+
+// MaxUploadAge is how long an unfinished multipart upload is remembered for
+// resuming. R2 aborts incomplete multipart uploads after seven days by
+// default, so older records cannot be resumed anyway.
+const MaxUploadAge = 7 * 24 * time.Hour
+
+type pendingUpload struct {
+	UploadID string    `json:"upload_id"`
+	Started  time.Time `json:"started"`
+}
+
+// GetUpload returns the multipart upload recorded under key, unless it is
+// older than MaxUploadAge.
+func GetUpload(key string) (string, bool) {
+	value, err := Get(UploadsBucket, []byte(key))
+	if err != nil || value == nil {
+		return "", false
+	}
+	var upload pendingUpload
+	if err := json.Unmarshal(value, &upload); err != nil || time.Since(upload.Started) > MaxUploadAge {
+		return "", false
+	}
+	return upload.UploadID, true
+}
+
+func SetUpload(key, uploadID string) error {
+	value, err := json.Marshal(pendingUpload{UploadID: uploadID, Started: time.Now()})
+	if err != nil {
+		return err
+	}
+	return Set(UploadsBucket, []byte(key), value)
+}
+
+func DeleteUpload(key string) error {
+	return Set(UploadsBucket, []byte(key), nil)
+}
+
+// pruneUploads forgets uploads older than MaxUploadAge.
+func pruneUploads(tx *bbolt.Tx) (int, error) {
+	b := tx.Bucket(UploadsBucket)
+	var stale [][]byte
+	err := b.ForEach(func(k, v []byte) error {
+		var upload pendingUpload
+		if err := json.Unmarshal(v, &upload); err != nil || time.Since(upload.Started) > MaxUploadAge {
+			stale = append(stale, append([]byte(nil), k...))
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	for _, k := range stale {
+		if err := b.Delete(k); err != nil {
+			return 0, err
+		}
+	}
+	return len(stale), nil
+}
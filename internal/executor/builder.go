@@ -7,6 +7,8 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"os/exec"
+	"slices"
 	"strings"
 	"time"
 
@@ -25,10 +27,26 @@ import (
 const (
 	ansiEraseLine   = "\r\x1b[2K"
 	DefaultCacheTTL = 5 * time.Minute
+	// MaxStaleAge bounds how old a cached result may be and still be shown
+	// in stale-while-revalidate mode.
+	MaxStaleAge = db.MaxCacheAge
 )
 
+// cacheHit describes the cached result the current command displayed.
+var cacheHit struct {
+	age        time.Duration
+	refreshing bool
+}
+
+// CachedAge reports how old the displayed result is when it came from the
+// cache, and whether it is being refreshed in the background.
+func CachedAge() (age time.Duration, refreshing bool, ok bool) {
+	return cacheHit.age, cacheHit.refreshing, cacheHit.age > 0
+}
+
 type CachedResult struct {
 	Timestamp time.Time       `json:"timestamp"`
+	TTL       time.Duration   `json:"ttl,omitempty"`
 	Data      json.RawMessage `json:"data"`
 }
 
@@ -38,6 +56,7 @@ type step struct {
 	run          func(ctx *Context, progress chan<- string) error
 	cacheKey     string
 	cacheKeyFunc func(*Context) string
+	cacheTTL     time.Duration
 }
 
 type ContextBuilder struct {
@@ -136,6 +155,7 @@ func (b *ContextBuilder) WithDNSRecord() *ContextBuilder {
 
 func (b *ContextBuilder) WithKVNamespace() *ContextBuilder {
 	b.steps = append(b.steps, step{
+		message: "Resolving KV namespace",
 		run: func(ctx *Context, _ chan<- string) error {
 			nsID, _ := ctx.Cmd.Flags().GetString("namespace-id")
 			if nsID == "" {
@@ -144,7 +164,80 @@ func (b *ContextBuilder) WithKVNamespace() *ContextBuilder {
 			if nsID == "" {
 				return fmt.Errorf("namespace ID is required. Use --namespace-id or 'cf kv namespace switch'")
 			}
-			ctx.KVNamespace = nsID
+			id, title, err := cloudflare.LookupKVNamespace(ctx.Client, ctx.AccountID, nsID)
+			if err != nil {
+				return err
+			}
+			ctx.KVNamespace = id
+			Set(ctx, KVNamespaceTitleKey, title)
+			return nil
+		},
+		silent: true,
+	})
+	return b
+}
+
+func (b *ContextBuilder) WithKVNamespaceArg() *ContextBuilder {
+	b.steps = append(b.steps, step{
+		message: "Resolving KV namespace",
+		run: func(ctx *Context, _ chan<- string) error {
+			id, title, err := cloudflare.LookupKVNamespace(ctx.Client, ctx.AccountID, ctx.Args[0])
+			if err != nil {
+				return err
+			}
+			ctx.KVNamespace = id
+			Set(ctx, KVNamespaceTitleKey, title)
+			return nil
+		},
+		silent: true,
+	})
+	return b
+}
+
+func (b *ContextBuilder) WithD1Database() *ContextBuilder {
+	b.steps = append(b.steps, step{
+		message: "Resolving D1 database",
+		run: func(ctx *Context, _ chan<- string) error {
+			id, name, err := cloudflare.LookupD1Database(ctx.Client, ctx.AccountID, ctx.Args[0])
+			if err != nil {
+				return err
+			}
+			Set(ctx, D1DatabaseIDKey, id)
+			Set(ctx, D1DatabaseNameKey, name)
+			return nil
+		},
+		silent: true,
+	})
+	return b
+}
+
+func (b *ContextBuilder) WithR2Bucket() *ContextBuilder {
+	b.steps = append(b.steps, step{
+		message: "Resolving R2 bucket",
+		run: func(ctx *Context, _ chan<- string) error {
+			jurisdiction, _ := ctx.Cmd.Flags().GetString("jurisdiction")
+			name, err := cloudflare.LookupR2Bucket(ctx.Client, ctx.AccountID, ctx.Args[0], jurisdiction)
+			if err != nil {
+				return err
+			}
+			Set(ctx, R2BucketNameKey, name)
+			return nil
+		},
+		silent: true,
+	})
+	return b
+}
+
+func (b *ContextBuilder) WithPagesProject() *ContextBuilder {
+	b.steps = append(b.steps, step{
+		message: "Resolving Pages project",
+		run: func(ctx *Context, _ chan<- string) error {
+			id, name, err := cloudflare.LookupPagesProject(ctx.Client, ctx.AccountID, ctx.Args[0])
+			if err != nil {
+				return err
+			}
+			Set(ctx, PagesProjectIDKey, id)
+			Set(ctx, PagesProjectNameKey, name)
 			return nil
 		},
 		silent: true,
@@ -172,6 +265,7 @@ func (b *ContextBuilder) Step(s StepRunner) *ContextBuilder {
 		run:          s.run,
 		cacheKey:     s.getCacheKey(),
 		cacheKeyFunc: s.getCacheKeyFunc(),
+		cacheTTL:     s.getCacheTTL(),
 	})
 	return b
 }
@@ -199,6 +293,9 @@ func (b *ContextBuilder) WithConfirmationFunc(fn func(ctx *Context) string) *Con
 				return nil
 			}
 			prompt := fn(ctx)
+			if prompt == "" {
+				return nil
+			}
 			confirmed, err := ui.Confirm(prompt)
 			if err != nil {
 				if errors.Is(err, huh.ErrUserAborted) {
@@ -224,7 +321,13 @@ func (b *ContextBuilder) Run() func(cmd *cobra.Command, args []string) {
 
 func (b *ContextBuilder) execute(cmd *cobra.Command, args []string) {
 	ctx := newContext(cmd, args)
-	writer := bufio.NewWriter(os.Stdout)
+	if revalidating, _ := cmd.Flags().GetBool(flags.RevalidateFlag); revalidating {
+		b.revalidate(ctx)
+		return
+	}
+	// Spinners go to stderr so commands that write data to stdout, such as
+	// kv namespace export, can be piped without progress frames mixed in.
+	writer := bufio.NewWriter(os.Stderr)
 	fmt.Fprintln(writer)
 
 	start := time.Now()
@@ -232,9 +335,12 @@ func (b *ContextBuilder) execute(cmd *cobra.Command, args []string) {
 	for i, s := range b.steps {
 		if b.hasCacheKey(s) && b.invalidatesFunc == nil && !b.skipCache && config.Cfg.Caching {
 			cacheKey := b.buildCacheKey(ctx, s)
-			if b.tryRestoreFromCache(ctx, cacheKey) {
+			if stale, ok := b.tryRestoreFromCache(ctx, cacheKey, s); ok {
 				ctx.Duration = time.Since(start)
 				b.displayFn(ctx)
+				if stale {
+					startRevalidation()
+				}
 				return
 			}
 		}
@@ -311,31 +417,99 @@ func (b *ContextBuilder) buildCacheKey(ctx *Context, s step) string {
 	return fmt.Sprintf("%x", h.Sum(nil))
 }
 
-func (b *ContextBuilder) tryRestoreFromCache(ctx *Context, cacheKey string) bool {
+// cacheTTL returns how long results of s stay fresh: the step's own TTL,
+// else the cache_ttl setting, else DefaultCacheTTL.
+func cacheTTL(s step) time.Duration {
+	if s.cacheTTL > 0 {
+		return s.cacheTTL
+	}
+	if config.Cfg.CacheTTL > 0 {
+		return config.Cfg.CacheTTL
+	}
+	return DefaultCacheTTL
+}
+
+// tryRestoreFromCache loads a cached result into ctx. In stale-while-revalidate
+// mode an expired result is still restored and reported as stale.
+func (b *ContextBuilder) tryRestoreFromCache(ctx *Context, cacheKey string, s step) (stale bool, ok bool) {
 	cachedBytes, _ := db.Get(db.CacheBucket, []byte(cacheKey))
 	if cachedBytes == nil {
-		return false
+		return false, false
 	}
 
 	var cachedResult CachedResult
 	if err := json.Unmarshal(cachedBytes, &cachedResult); err != nil {
-		return false
+		return false, false
 	}
 
-	if time.Since(cachedResult.Timestamp) > DefaultCacheTTL {
-		return false
+	age := time.Since(cachedResult.Timestamp)
+	if age > cacheTTL(s) {
+		if !config.Cfg.StaleWhileRevalidate || age > MaxStaleAge {
+			return false, false
+		}
+		stale = true
 	}
 
 	var dataMap map[string]json.RawMessage
 	if err := json.Unmarshal(cachedResult.Data, &dataMap); err != nil {
-		return false
+		return false, false
 	}
 
 	for k, v := range dataMap {
 		ctx.data[k] = v
 	}
 
-	return true
+	cacheHit.age = max(age, time.Second)
+	cacheHit.refreshing = stale
+	return stale, true
+}
+
+// startRevalidation reruns the current command in a detached process with
+// --revalidate after a stale result has been displayed, so the next call
+// finds a fresh cache without this one waiting for the refresh. The child
+// blocks on the database lock until this process exits.
+func startRevalidation() {
+	exe, err := os.Executable()
+	if err != nil {
+		return
+	}
+	args := slices.Clone(os.Args[1:])
+	flag := "--" + flags.RevalidateFlag
+	if i := slices.Index(args, "--"); i >= 0 {
+		args = slices.Insert(args, i, flag)
+	} else {
+		args = append(args, flag)
+	}
+	child := exec.Command(exe, args...)
+	child.SysProcAttr = detachedProcAttr()
+	if err := child.Start(); err != nil {
+		return
+	}
+	_ = child.Process.Release()
+}
+
+// revalidate runs every step without any output and stores the results, for
+// a command started with --revalidate.
+func (b *ContextBuilder) revalidate(ctx *Context) {
+	progress := make(chan string)
+	go func() {
+		for range progress {
+		}
+	}()
+	defer close(progress)
+
+	for i, s := range b.steps {
+		var p chan<- string
+		if !s.silent {
+			p = progress
+		}
+		if err := s.run(ctx, p); err != nil {
+			return
+		}
+		if b.hasCacheKey(s) {
+			b.storeToCache(ctx, b.buildCacheKey(ctx, s), b.steps[i:])
+		}
+	}
 }
 
 func (b *ContextBuilder) storeToCache(ctx *Context, cacheKey string, steps []step) {
@@ -346,6 +520,7 @@ func (b *ContextBuilder) storeToCache(ctx *Context, cacheKey string, steps []ste
 
 	resultToStore := CachedResult{
 		Timestamp: time.Now(),
+		TTL:       cacheTTL(steps[0]),
 		Data:      dataToCache,
 	}
 
@@ -359,14 +534,27 @@ func (b *ContextBuilder) storeToCache(ctx *Context, cacheKey string, steps []ste
 	for _, s := range steps {
 		if s.cacheKey != "" {
 			_ = db.AddTagsToKey(cacheKey, []string{s.cacheKey})
+		} else if s.cacheKeyFunc != nil {
+			if tag := s.cacheKeyFunc(ctx); tag != "" {
+				_ = db.AddTagsToKey(cacheKey, []string{tag})
+			}
 		}
 	}
 }
 
+// TryProgress reports msg without blocking. It is dropped when the previous
+// update has not been picked up yet, so hot loops never wait on the spinner.
+func TryProgress(progress chan<- string, msg string) {
+	select {
+	case progress <- msg:
+	default:
+	}
+}
+
 func runStep(writer *bufio.Writer, message string, task func(progress chan<- string) error) error {
 	s := ui.StyledSpinner()
 	resultChan := make(chan error, 1)
-	progressChan := make(chan string)
+	progressChan := make(chan string, 1)
 	currentMessage := message
 
 	go func() {
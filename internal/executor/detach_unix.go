@@ -0,0 +1,11 @@
+//go:build !windows
+
+package executor
+
+import "syscall"
+
+// detachedProcAttr starts the child in its own session, so it survives the
+// terminal closing and doesn't receive the terminal's signals.
+func detachedProcAttr() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{Setsid: true}
+}
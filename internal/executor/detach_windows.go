@@ -0,0 +1,16 @@
+//go:build windows
+
+package executor
+
+import "syscall"
+
+const (
+	createNewProcessGroup = 0x00000200
+	detachedProcess       = 0x00000008
+)
+
+// detachedProcAttr starts the child without a console and in its own process
+// group, so it outlives the parent and ignores Ctrl+C.
+func detachedProcAttr() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{CreationFlags: createNewProcessGroup | detachedProcess}
+}
@@ -17,4 +17,11 @@ var (
 	ZoneNameKey   = NewKey[string]("zoneName")
 	RecordIDKey   = NewKey[string]("recordID")
 	RecordNameKey = NewKey[string]("recordName")
+
+	KVNamespaceTitleKey = NewKey[string]("kvNamespaceTitle")
+	D1DatabaseIDKey     = NewKey[string]("d1DatabaseID")
+	D1DatabaseNameKey   = NewKey[string]("d1DatabaseName")
+	R2BucketNameKey     = NewKey[string]("r2BucketName")
+	PagesProjectIDKey   = NewKey[string]("pagesProjectID")
+	PagesProjectNameKey = NewKey[string]("pagesProjectName")
 )
@@ -0,0 +1,37 @@
+package executor
+
+import "dario.lol/cf/internal/cloudflare"
+
+// PhaseRules returns a step function fetching the rules of p in the zone
+// resolved by WithZone.
+func PhaseRules(p cloudflare.ZonePhase) func(*Context, chan<- string) (*cloudflare.Ruleset, error) {
+	return func(ctx *Context, _ chan<- string) (*cloudflare.Ruleset, error) {
+		return p.Rules(ctx.Client, Get(ctx, ZoneIDKey))
+	}
+}
+
+// PhaseRule returns a step function resolving the rule named by the second
+// argument. The phase's rules are kept under rulesetKey for later steps.
+func PhaseRule(p cloudflare.ZonePhase, rulesetKey Key[*cloudflare.Ruleset]) func(*Context, chan<- string) (*cloudflare.Rule, error) {
+	return func(ctx *Context, _ chan<- string) (*cloudflare.Rule, error) {
+		ruleset, rule, err := p.FindRule(ctx.Client, Get(ctx, ZoneIDKey), ctx.Args[1])
+		if err != nil {
+			return nil, err
+		}
+		Set(ctx, rulesetKey, ruleset)
+		return rule, nil
+	}
+}
+
+func PhaseCacheKey(p cloudflare.ZonePhase) func(*Context) string {
+	return func(ctx *Context) string {
+		return p.CacheKey(Get(ctx, ZoneIDKey))
+	}
+}
+
+// PhaseTags invalidates the cached rules of p for every command.
+func PhaseTags(p cloudflare.ZonePhase) func(*Context) []string {
+	return func(ctx *Context) []string {
+		return []string{p.CacheTag(Get(ctx, ZoneIDKey))}
+	}
+}
@@ -1,11 +1,14 @@
 package executor
 
+import "time"
+
 type StepRunner interface {
 	run(ctx *Context, progress chan<- string) error
 	getMessage() string
 	isSilent() bool
 	getCacheKey() string
 	getCacheKeyFunc() func(*Context) string
+	getCacheTTL() time.Duration
 }
 
 type Step[T any] struct {
@@ -15,6 +18,7 @@ type Step[T any] struct {
 	silent       bool
 	cacheKey     string
 	cacheKeyFunc func(*Context) string
+	cacheTTL     time.Duration
 }
 
 func NewStep[T any](key Key[T], message string) *Step[T] {
@@ -41,6 +45,13 @@ func (s *Step[T]) CacheKeyFunc(fn func(*Context) string) *Step[T] {
 	return s
 }
 
+// CacheTTL sets how long the step's cached result stays fresh, overriding the
+// cache_ttl setting.
+func (s *Step[T]) CacheTTL(ttl time.Duration) *Step[T] {
+	s.cacheTTL = ttl
+	return s
+}
+
 func (s *Step[T]) run(ctx *Context, progress chan<- string) error {
 	result, err := s.fn(ctx, progress)
 	if err != nil {
@@ -65,3 +76,7 @@ func (s *Step[T]) getCacheKey() string {
 func (s *Step[T]) getCacheKeyFunc() func(*Context) string {
 	return s.cacheKeyFunc
 }
+
+func (s *Step[T]) getCacheTTL() time.Duration {
+	return s.cacheTTL
+}
@@ -5,6 +5,9 @@ import "github.com/spf13/cobra"
 const (
 	AccountIDFlag = "account-id"
 	YesFlag       = "yes"
+	// RevalidateFlag makes a command refresh its cached results without any
+	// output. It is how stale-while-revalidate refreshes in the background.
+	RevalidateFlag = "revalidate"
 )
 
 func RegisterAccountID(cmd *cobra.Command) {
@@ -14,3 +17,8 @@ func RegisterAccountID(cmd *cobra.Command) {
 func RegisterConfirmation(cmd *cobra.Command) {
 	cmd.Flags().BoolP(YesFlag, "y", false, "Skip confirmation prompt")
 }
+
+func RegisterRevalidate(cmd *cobra.Command) {
+	cmd.PersistentFlags().Bool(RevalidateFlag, false, "Refresh cached results without output")
+	_ = cmd.PersistentFlags().MarkHidden(RevalidateFlag)
+}
@@ -0,0 +1,154 @@
+package s3
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const DefaultRegion = "auto"
+
+// Client talks to an S3-compatible endpoint such as R2 using path-style
+// addressing and SigV4 signed requests.
+type Client struct {
+	Endpoint        string
+	Region          string
+	AccessKeyID     string
+	SecretAccessKey string
+	HTTPClient      *http.Client
+}
+
+func New(endpoint, accessKeyID, secretAccessKey string) *Client {
+	return &Client{
+		Endpoint:        strings.TrimSuffix(endpoint, "/"),
+		Region:          DefaultRegion,
+		AccessKeyID:     accessKeyID,
+		SecretAccessKey: secretAccessKey,
+		HTTPClient:      http.DefaultClient,
+	}
+}
+
+type Error struct {
+	StatusCode int
+	Code       string `xml:"Code"`
+	Message    string `xml:"Message"`
+}
+
+func (e *Error) Error() string {
+	if e.Code == "" {
+		return fmt.Sprintf("S3 request failed with status %d", e.StatusCode)
+	}
+	if e.Message == "" {
+		return fmt.Sprintf("%s (status %d)", e.Code, e.StatusCode)
+	}
+	return fmt.Sprintf("%s: %s (status %d)", e.Code, e.Message, e.StatusCode)
+}
+
+// IsNotFound reports whether err is an S3 error for a missing bucket, key or upload.
+func IsNotFound(err error) bool {
+	if e, ok := err.(*Error); ok {
+		return e.StatusCode == http.StatusNotFound
+	}
+	return false
+}
+
+type request struct {
+	method        string
+	bucket        string
+	key           string
+	query         url.Values
+	header        http.Header
+	body          io.Reader
+	contentLength int64
+	payloadHash   string
+}
+
+func (c *Client) do(ctx context.Context, r request) (*http.Response, error) {
+	endpoint, err := url.Parse(c.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid S3 endpoint %q: %w", c.Endpoint, err)
+	}
+
+	path := "/" + r.bucket
+	if r.key != "" {
+		path += "/" + r.key
+	}
+	u := &url.URL{
+		Scheme:   endpoint.Scheme,
+		Host:     endpoint.Host,
+		Path:     path,
+		RawPath:  uriEncode(path, false),
+		RawQuery: canonicalQuery(r.query),
+	}
+
+	req, err := http.NewRequestWithContext(ctx, r.method, u.String(), r.body)
+	if err != nil {
+		return nil, err
+	}
+	for name, values := range r.header {
+		for _, v := range values {
+			req.Header.Add(name, v)
+		}
+	}
+	if r.body != nil {
+		req.ContentLength = r.contentLength
+	}
+
+	payloadHash := r.payloadHash
+	if payloadHash == "" {
+		payloadHash = emptyPayloadHash
+		if r.body != nil {
+			payloadHash = unsignedPayload
+		}
+	}
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	c.sign(req, time.Now())
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		s3Err := &Error{StatusCode: resp.StatusCode}
+		if body, readErr := io.ReadAll(resp.Body); readErr == nil && len(body) > 0 {
+			_ = xml.Unmarshal(body, s3Err)
+		}
+		return nil, s3Err
+	}
+	return resp, nil
+}
+
+// doXML sends a request whose body is XML and decodes an XML response into out.
+func (c *Client) doXML(ctx context.Context, r request, in any, out any) error {
+	if in != nil {
+		body, err := xml.Marshal(in)
+		if err != nil {
+			return err
+		}
+		r.body = strings.NewReader(string(body))
+		r.contentLength = int64(len(body))
+		r.payloadHash = hashHex(body)
+		if r.header == nil {
+			r.header = http.Header{}
+		}
+		r.header.Set("Content-Type", "application/xml")
+	}
+
+	resp, err := c.do(ctx, r)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if out == nil {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+	return xml.NewDecoder(resp.Body).Decode(out)
+}
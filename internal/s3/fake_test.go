@@ -0,0 +1,276 @@
+package s3
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeS3 is an in-memory stand-in for the parts of the S3 API the client
+// uses, with path-style addressing.
+type fakeS3 struct {
+	mu       sync.Mutex
+	objects  map[string][]byte
+	uploads  map[string]*fakeUpload
+	aborted  []string
+	nextID   int
+	pageSize int
+	// failPart makes uploads of this part number fail.
+	failPart int
+}
+
+type fakeUpload struct {
+	key   string
+	parts map[int][]byte
+}
+
+func newFakeS3(t *testing.T) (*fakeS3, *Client) {
+	fake := &fakeS3{objects: map[string][]byte{}, uploads: map[string]*fakeUpload{}}
+	srv := httptest.NewServer(fake)
+	t.Cleanup(srv.Close)
+	c := New(srv.URL, "id", "secret")
+	c.HTTPClient = srv.Client()
+	return fake, c
+}
+
+func etagOf(data []byte) string {
+	sum := md5.Sum(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.Header.Get("Authorization"), signingAlgorithm+" ") {
+		http.Error(w, "unsigned request", http.StatusForbidden)
+		return
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	bucket, key, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	path := bucket + "/" + key
+	q := r.URL.Query()
+	uploadID := q.Get("uploadId")
+
+	switch {
+	case r.Method == http.MethodGet && key == "" && q.Get("list-type") == "2":
+		f.list(w, bucket, q)
+	case r.Method == http.MethodPost && q.Has("uploads"):
+		f.nextID++
+		id := fmt.Sprintf("upload-%d", f.nextID)
+		f.uploads[id] = &fakeUpload{key: path, parts: map[int][]byte{}}
+		writeXML(w, struct {
+			XMLName  xml.Name `xml:"InitiateMultipartUploadResult"`
+			UploadID string   `xml:"UploadId"`
+		}{UploadID: id})
+	case uploadID != "" && f.uploads[uploadID] == nil:
+		w.WriteHeader(http.StatusNotFound)
+		writeXML(w, struct {
+			XMLName xml.Name `xml:"Error"`
+			Code    string   `xml:"Code"`
+		}{Code: "NoSuchUpload"})
+	case r.Method == http.MethodPut && uploadID != "":
+		n, _ := strconv.Atoi(q.Get("partNumber"))
+		data, _ := io.ReadAll(r.Body)
+		if n == f.failPart {
+			http.Error(w, "injected failure", http.StatusInternalServerError)
+			return
+		}
+		f.uploads[uploadID].parts[n] = data
+		w.Header().Set("ETag", `"`+etagOf(data)+`"`)
+	case r.Method == http.MethodGet && uploadID != "":
+		type part struct {
+			PartNumber int    `xml:"PartNumber"`
+			ETag       string `xml:"ETag"`
+			Size       int    `xml:"Size"`
+		}
+		var out struct {
+			XMLName xml.Name `xml:"ListPartsResult"`
+			Parts   []part   `xml:"Part"`
+		}
+		upload := f.uploads[uploadID]
+		for _, n := range slices.Sorted(maps.Keys(upload.parts)) {
+			out.Parts = append(out.Parts, part{n, `"` + etagOf(upload.parts[n]) + `"`, len(upload.parts[n])})
+		}
+		writeXML(w, out)
+	case r.Method == http.MethodPost && uploadID != "":
+		var body completeMultipartUpload
+		if err := xml.NewDecoder(r.Body).Decode(&body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		upload := f.uploads[uploadID]
+		var object []byte
+		for _, p := range body.Parts {
+			data, ok := upload.parts[p.PartNumber]
+			if !ok || strings.Trim(p.ETag, `"`) != etagOf(data) {
+				http.Error(w, "invalid part", http.StatusBadRequest)
+				return
+			}
+			object = append(object, data...)
+		}
+		f.objects[upload.key] = object
+		delete(f.uploads, uploadID)
+		writeXML(w, struct {
+			XMLName xml.Name `xml:"CompleteMultipartUploadResult"`
+			ETag    string   `xml:"ETag"`
+		}{ETag: `"multipart"`})
+	case r.Method == http.MethodDelete && uploadID != "":
+		delete(f.uploads, uploadID)
+		f.aborted = append(f.aborted, uploadID)
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodPut:
+		data, _ := io.ReadAll(r.Body)
+		f.objects[path] = data
+		w.Header().Set("ETag", `"`+etagOf(data)+`"`)
+	default:
+		http.Error(w, "unsupported request", http.StatusNotImplemented)
+	}
+}
+
+// list serves ListObjectsV2. Continuation tokens are the index of the next
+// key, and pages hold at most pageSize keys when it is set.
+func (f *fakeS3) list(w http.ResponseWriter, bucket string, q map[string][]string) {
+	get := func(name string) string {
+		if v := q[name]; len(v) > 0 {
+			return v[0]
+		}
+		return ""
+	}
+	var keys []string
+	for path := range f.objects {
+		key, ok := strings.CutPrefix(path, bucket+"/")
+		if ok && strings.HasPrefix(key, get("prefix")) {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+
+	start, _ := strconv.Atoi(get("continuation-token"))
+	maxKeys := 1000
+	if v, err := strconv.Atoi(get("max-keys")); err == nil {
+		maxKeys = v
+	}
+	if f.pageSize > 0 {
+		maxKeys = min(maxKeys, f.pageSize)
+	}
+	end := min(start+maxKeys, len(keys))
+
+	type content struct {
+		Key          string `xml:"Key"`
+		Size         int    `xml:"Size"`
+		ETag         string `xml:"ETag"`
+		LastModified string `xml:"LastModified"`
+	}
+	var out struct {
+		XMLName               xml.Name  `xml:"ListBucketResult"`
+		IsTruncated           bool      `xml:"IsTruncated"`
+		NextContinuationToken string    `xml:"NextContinuationToken,omitempty"`
+		Contents              []content `xml:"Contents"`
+	}
+	for _, key := range keys[start:end] {
+		data := f.objects[bucket+"/"+key]
+		out.Contents = append(out.Contents, content{key, len(data), `"` + etagOf(data) + `"`, "2024-01-02T03:04:05.000Z"})
+	}
+	if end < len(keys) {
+		out.IsTruncated = true
+		out.NextContinuationToken = strconv.Itoa(end)
+	}
+	writeXML(w, out)
+}
+
+func writeXML(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/xml")
+	_ = xml.NewEncoder(w).Encode(v)
+}
+
+func TestListObjectsParsesResponse(t *testing.T) {
+	var query string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		fmt.Fprint(w, `<?xml version="1.0" encoding="UTF-8"?>
+<ListBucketResult xmlns="http://s3.amazonaws.com/doc/2006-03-01/">
+  <Name>bucket</Name>
+  <Prefix>photos/</Prefix>
+  <IsTruncated>true</IsTruncated>
+  <NextContinuationToken>next-page</NextContinuationToken>
+  <Contents>
+    <Key>photos/a.jpg</Key>
+    <LastModified>2024-01-02T03:04:05.000Z</LastModified>
+    <ETag>"9b2cf535f27731c974343645a3985328"</ETag>
+    <Size>1024</Size>
+    <StorageClass>STANDARD</StorageClass>
+  </Contents>
+  <CommonPrefixes><Prefix>photos/2024/</Prefix></CommonPrefixes>
+  <CommonPrefixes><Prefix>photos/2025/</Prefix></CommonPrefixes>
+</ListBucketResult>`)
+	}))
+	defer srv.Close()
+	c := New(srv.URL, "id", "secret")
+
+	page, err := c.ListObjects(t.Context(), "bucket", ListOptions{Prefix: "photos/", Delimiter: "/", MaxKeys: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if query != "delimiter=%2F&list-type=2&max-keys=10&prefix=photos%2F" {
+		t.Errorf("query = %s", query)
+	}
+	if !page.IsTruncated || page.NextContinuationToken != "next-page" {
+		t.Errorf("truncation = %v %q", page.IsTruncated, page.NextContinuationToken)
+	}
+	want := ObjectInfo{
+		Key:          "photos/a.jpg",
+		Size:         1024,
+		ETag:         "9b2cf535f27731c974343645a3985328",
+		LastModified: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		StorageClass: "STANDARD",
+	}
+	if len(page.Objects) != 1 || !reflect.DeepEqual(page.Objects[0], want) {
+		t.Errorf("objects = %+v, want %+v", page.Objects, want)
+	}
+	if !slices.Equal(page.CommonPrefixes, []string{"photos/2024/", "photos/2025/"}) {
+		t.Errorf("common prefixes = %v", page.CommonPrefixes)
+	}
+}
+
+func TestListAllObjectsFollowsPages(t *testing.T) {
+	fake, c := newFakeS3(t)
+	fake.pageSize = 2
+	for i := range 5 {
+		fake.objects[fmt.Sprintf("bucket/key-%d", i)] = bytes.Repeat([]byte{'x'}, i)
+	}
+
+	pages := 0
+	all, err := c.ListAllObjects(t.Context(), "bucket", ListOptions{}, 0, func(*ListResult) { pages++ })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all.Objects) != 5 || all.IsTruncated || pages != 3 {
+		t.Fatalf("got %d objects in %d pages, truncated %v", len(all.Objects), pages, all.IsTruncated)
+	}
+	for i, obj := range all.Objects {
+		if obj.Key != fmt.Sprintf("key-%d", i) || obj.Size != int64(i) {
+			t.Errorf("object %d = %+v", i, obj)
+		}
+	}
+
+	limited, err := c.ListAllObjects(t.Context(), "bucket", ListOptions{}, 3, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(limited.Objects) != 3 || !limited.IsTruncated || limited.NextContinuationToken != "3" {
+		t.Errorf("limited listing = %d objects, truncated %v, token %q", len(limited.Objects), limited.IsTruncated, limited.NextContinuationToken)
+	}
+}
@@ -0,0 +1,150 @@
+package s3
+
+import (
+	"context"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	MinPartSize = 5 << 20
+	MaxParts    = 10000
+)
+
+type Part struct {
+	PartNumber int    `xml:"PartNumber"`
+	ETag       string `xml:"ETag"`
+	Size       int64  `xml:"Size,omitempty"`
+}
+
+type Upload struct {
+	Key       string    `xml:"Key"`
+	UploadID  string    `xml:"UploadId"`
+	Initiated time.Time `xml:"Initiated"`
+}
+
+func (c *Client) CreateMultipartUpload(ctx context.Context, bucket, key string, opts PutOptions) (string, error) {
+	var out struct {
+		UploadID string `xml:"UploadId"`
+	}
+	err := c.doXML(ctx, request{
+		method: http.MethodPost,
+		bucket: bucket,
+		key:    key,
+		query:  url.Values{"uploads": {""}},
+		header: opts.header(),
+	}, nil, &out)
+	return out.UploadID, err
+}
+
+func (c *Client) UploadPart(ctx context.Context, bucket, key, uploadID string, partNumber int, body io.Reader, size int64) (string, error) {
+	resp, err := c.do(ctx, request{
+		method: http.MethodPut,
+		bucket: bucket,
+		key:    key,
+		query: url.Values{
+			"partNumber": {strconv.Itoa(partNumber)},
+			"uploadId":   {uploadID},
+		},
+		body:          body,
+		contentLength: size,
+	})
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	return strings.Trim(resp.Header.Get("ETag"), `"`), nil
+}
+
+type completeMultipartUpload struct {
+	XMLName xml.Name `xml:"CompleteMultipartUpload"`
+	Parts   []Part   `xml:"Part"`
+}
+
+func (c *Client) CompleteMultipartUpload(ctx context.Context, bucket, key, uploadID string, parts []Part) (string, error) {
+	body := completeMultipartUpload{}
+	for _, p := range parts {
+		body.Parts = append(body.Parts, Part{PartNumber: p.PartNumber, ETag: `"` + p.ETag + `"`})
+	}
+
+	var out struct {
+		ETag string `xml:"ETag"`
+	}
+	err := c.doXML(ctx, request{
+		method: http.MethodPost,
+		bucket: bucket,
+		key:    key,
+		query:  url.Values{"uploadId": {uploadID}},
+	}, body, &out)
+	return strings.Trim(out.ETag, `"`), err
+}
+
+func (c *Client) AbortMultipartUpload(ctx context.Context, bucket, key, uploadID string) error {
+	resp, err := c.do(ctx, request{
+		method: http.MethodDelete,
+		bucket: bucket,
+		key:    key,
+		query:  url.Values{"uploadId": {uploadID}},
+	})
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// ListMultipartUploads returns the in-progress uploads whose key starts with prefix.
+func (c *Client) ListMultipartUploads(ctx context.Context, bucket, prefix string) ([]Upload, error) {
+	query := url.Values{"uploads": {""}}
+	if prefix != "" {
+		query.Set("prefix", prefix)
+	}
+
+	var uploads []Upload
+	for {
+		var out struct {
+			Uploads            []Upload `xml:"Upload"`
+			IsTruncated        bool     `xml:"IsTruncated"`
+			NextKeyMarker      string   `xml:"NextKeyMarker"`
+			NextUploadIDMarker string   `xml:"NextUploadIdMarker"`
+		}
+		if err := c.doXML(ctx, request{method: http.MethodGet, bucket: bucket, query: query}, nil, &out); err != nil {
+			return nil, err
+		}
+		uploads = append(uploads, out.Uploads...)
+		if !out.IsTruncated {
+			return uploads, nil
+		}
+		query.Set("key-marker", out.NextKeyMarker)
+		query.Set("upload-id-marker", out.NextUploadIDMarker)
+	}
+}
+
+func (c *Client) ListParts(ctx context.Context, bucket, key, uploadID string) ([]Part, error) {
+	query := url.Values{"uploadId": {uploadID}}
+
+	var parts []Part
+	for {
+		var out struct {
+			Parts                []Part `xml:"Part"`
+			IsTruncated          bool   `xml:"IsTruncated"`
+			NextPartNumberMarker string `xml:"NextPartNumberMarker"`
+		}
+		if err := c.doXML(ctx, request{method: http.MethodGet, bucket: bucket, key: key, query: query}, nil, &out); err != nil {
+			return nil, err
+		}
+		for _, p := range out.Parts {
+			p.ETag = strings.Trim(p.ETag, `"`)
+			parts = append(parts, p)
+		}
+		if !out.IsTruncated {
+			return parts, nil
+		}
+		query.Set("part-number-marker", out.NextPartNumberMarker)
+	}
+}
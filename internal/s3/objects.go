@@ -0,0 +1,205 @@
+package s3
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const metadataHeaderPrefix = "X-Amz-Meta-"
+
+type ObjectInfo struct {
+	Key          string            `json:"key"`
+	Size         int64             `json:"size"`
+	ETag         string            `json:"etag"`
+	LastModified time.Time         `json:"last_modified"`
+	ContentType  string            `json:"content_type,omitempty"`
+	StorageClass string            `json:"storage_class,omitempty"`
+	Metadata     map[string]string `json:"metadata,omitempty"`
+}
+
+type PutOptions struct {
+	ContentType string
+	Metadata    map[string]string
+}
+
+func (o PutOptions) header() http.Header {
+	header := http.Header{}
+	if o.ContentType != "" {
+		header.Set("Content-Type", o.ContentType)
+	}
+	for k, v := range o.Metadata {
+		header.Set(metadataHeaderPrefix+k, v)
+	}
+	return header
+}
+
+func (c *Client) PutObject(ctx context.Context, bucket, key string, body io.Reader, size int64, opts PutOptions) (string, error) {
+	resp, err := c.do(ctx, request{
+		method:        http.MethodPut,
+		bucket:        bucket,
+		key:           key,
+		header:        opts.header(),
+		body:          body,
+		contentLength: size,
+	})
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	return strings.Trim(resp.Header.Get("ETag"), `"`), nil
+}
+
+// GetObject returns the object body, which the caller must close.
+func (c *Client) GetObject(ctx context.Context, bucket, key string) (io.ReadCloser, ObjectInfo, error) {
+	resp, err := c.do(ctx, request{method: http.MethodGet, bucket: bucket, key: key})
+	if err != nil {
+		return nil, ObjectInfo{}, err
+	}
+	return resp.Body, objectInfoFromHeader(key, resp.Header), nil
+}
+
+func (c *Client) HeadObject(ctx context.Context, bucket, key string) (ObjectInfo, error) {
+	resp, err := c.do(ctx, request{method: http.MethodHead, bucket: bucket, key: key})
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	resp.Body.Close()
+	return objectInfoFromHeader(key, resp.Header), nil
+}
+
+func (c *Client) DeleteObject(ctx context.Context, bucket, key string) error {
+	resp, err := c.do(ctx, request{method: http.MethodDelete, bucket: bucket, key: key})
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+func objectInfoFromHeader(key string, header http.Header) ObjectInfo {
+	info := ObjectInfo{
+		Key:          key,
+		ETag:         strings.Trim(header.Get("ETag"), `"`),
+		ContentType:  header.Get("Content-Type"),
+		StorageClass: header.Get("X-Amz-Storage-Class"),
+	}
+	info.Size, _ = strconv.ParseInt(header.Get("Content-Length"), 10, 64)
+	info.LastModified, _ = time.Parse(http.TimeFormat, header.Get("Last-Modified"))
+	for name, values := range header {
+		if strings.HasPrefix(name, metadataHeaderPrefix) && len(values) > 0 {
+			if info.Metadata == nil {
+				info.Metadata = make(map[string]string)
+			}
+			info.Metadata[strings.ToLower(strings.TrimPrefix(name, metadataHeaderPrefix))] = values[0]
+		}
+	}
+	return info
+}
+
+type ListOptions struct {
+	Prefix            string
+	Delimiter         string
+	ContinuationToken string
+	StartAfter        string
+	MaxKeys           int
+}
+
+type ListResult struct {
+	Objects               []ObjectInfo `json:"objects"`
+	CommonPrefixes        []string     `json:"common_prefixes"`
+	IsTruncated           bool         `json:"is_truncated"`
+	NextContinuationToken string       `json:"next_continuation_token,omitempty"`
+}
+
+type listBucketResult struct {
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+	Contents              []struct {
+		Key          string    `xml:"Key"`
+		Size         int64     `xml:"Size"`
+		ETag         string    `xml:"ETag"`
+		LastModified time.Time `xml:"LastModified"`
+		StorageClass string    `xml:"StorageClass"`
+	} `xml:"Contents"`
+	CommonPrefixes []struct {
+		Prefix string `xml:"Prefix"`
+	} `xml:"CommonPrefixes"`
+}
+
+// ListObjects returns a single page of a ListObjectsV2 listing.
+func (c *Client) ListObjects(ctx context.Context, bucket string, opts ListOptions) (*ListResult, error) {
+	query := url.Values{"list-type": {"2"}}
+	if opts.Prefix != "" {
+		query.Set("prefix", opts.Prefix)
+	}
+	if opts.Delimiter != "" {
+		query.Set("delimiter", opts.Delimiter)
+	}
+	if opts.ContinuationToken != "" {
+		query.Set("continuation-token", opts.ContinuationToken)
+	}
+	if opts.StartAfter != "" {
+		query.Set("start-after", opts.StartAfter)
+	}
+	if opts.MaxKeys > 0 {
+		query.Set("max-keys", strconv.Itoa(opts.MaxKeys))
+	}
+
+	var out listBucketResult
+	if err := c.doXML(ctx, request{method: http.MethodGet, bucket: bucket, query: query}, nil, &out); err != nil {
+		return nil, err
+	}
+
+	result := &ListResult{
+		IsTruncated:           out.IsTruncated,
+		NextContinuationToken: out.NextContinuationToken,
+	}
+	for _, obj := range out.Contents {
+		result.Objects = append(result.Objects, ObjectInfo{
+			Key:          obj.Key,
+			Size:         obj.Size,
+			ETag:         strings.Trim(obj.ETag, `"`),
+			LastModified: obj.LastModified,
+			StorageClass: obj.StorageClass,
+		})
+	}
+	for _, p := range out.CommonPrefixes {
+		result.CommonPrefixes = append(result.CommonPrefixes, p.Prefix)
+	}
+	return result, nil
+}
+
+// ListAllObjects follows continuation tokens until the listing is complete or
+// limit objects have been collected (0 = no limit).
+func (c *Client) ListAllObjects(ctx context.Context, bucket string, opts ListOptions, limit int, onPage func(*ListResult)) (*ListResult, error) {
+	all := &ListResult{}
+	for {
+		if limit > 0 {
+			opts.MaxKeys = min(1000, limit-len(all.Objects))
+		}
+		page, err := c.ListObjects(ctx, bucket, opts)
+		if err != nil {
+			return nil, err
+		}
+		all.Objects = append(all.Objects, page.Objects...)
+		all.CommonPrefixes = append(all.CommonPrefixes, page.CommonPrefixes...)
+		if onPage != nil {
+			onPage(all)
+		}
+
+		if !page.IsTruncated || page.NextContinuationToken == "" {
+			return all, nil
+		}
+		if limit > 0 && len(all.Objects) >= limit {
+			all.IsTruncated = true
+			all.NextContinuationToken = page.NextContinuationToken
+			return all, nil
+		}
+		opts.ContinuationToken = page.NextContinuationToken
+	}
+}
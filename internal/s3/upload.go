@@ -0,0 +1,231 @@
+package s3
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"sort"
+	"sync/atomic"
+
+	"github.com/alitto/pond/v2"
+)
+
+const (
+	DefaultPartSize    = 16 << 20
+	DefaultConcurrency = 4
+)
+
+// ResumeStore remembers unfinished multipart uploads between runs.
+type ResumeStore interface {
+	Get(key string) (string, bool)
+	Set(key, uploadID string)
+	Delete(key string)
+}
+
+type UploadOptions struct {
+	PutOptions
+	PartSize    int64
+	Concurrency int
+	// Resume enables resuming a failed multipart upload. Only an upload
+	// recorded for the same key, options and Fingerprint is resumed. Without
+	// it, a failed multipart upload is aborted.
+	Resume ResumeStore
+	// Fingerprint identifies the local data, e.g. its path, size and
+	// modification time.
+	Fingerprint string
+	// Progress is called with the number of bytes uploaded so far, including
+	// parts reused from a resumed upload.
+	Progress func(uploaded, total int64)
+}
+
+type UploadResult struct {
+	ETag        string
+	Multipart   bool
+	Parts       int
+	ResumedFrom int
+}
+
+// PartSizeFor returns the smallest part size of at least partSize that keeps
+// an upload of size bytes within the multipart part limit.
+func PartSizeFor(size, partSize int64) int64 {
+	if partSize < MinPartSize {
+		partSize = MinPartSize
+	}
+	for (size+partSize-1)/partSize > MaxParts {
+		partSize *= 2
+	}
+	return partSize
+}
+
+// Upload stores size bytes from r under key. Objects larger than one part are
+// sent as a multipart upload with parts uploaded in parallel. With
+// opts.Resume set, a failed upload is left in place and the next attempt with
+// the same fingerprint reuses the parts already sent, after checking each
+// against the MD5 of the local data. If any part differs, the stale upload is
+// aborted and a new one started. Without opts.Resume, a failed upload is
+// aborted so no parts are left behind.
+func (c *Client) Upload(ctx context.Context, bucket, key string, r io.ReaderAt, size int64, opts UploadOptions) (*UploadResult, error) {
+	partSize := opts.PartSize
+	if partSize == 0 {
+		partSize = DefaultPartSize
+	}
+	partSize = PartSizeFor(size, partSize)
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultConcurrency
+	}
+	report := func(uploaded int64) {
+		if opts.Progress != nil {
+			opts.Progress(uploaded, size)
+		}
+	}
+
+	if size <= partSize {
+		etag, err := c.PutObject(ctx, bucket, key, io.NewSectionReader(r, 0, size), size, opts.PutOptions)
+		if err != nil {
+			return nil, err
+		}
+		report(size)
+		return &UploadResult{ETag: etag, Parts: 1}, nil
+	}
+
+	partCount := int((size + partSize - 1) / partSize)
+	partLength := func(n int) int64 {
+		return min(partSize, size-int64(n-1)*partSize)
+	}
+
+	resumeKey := uploadResumeKey(bucket, key, size, partSize, opts)
+	uploadID, existing, err := c.findResumableUpload(ctx, bucket, key, opts.Resume, resumeKey)
+	if err != nil {
+		return nil, err
+	}
+	if uploadID != "" {
+		for n, p := range existing {
+			if n > partCount || p.Size != partLength(n) || !partMatches(r, int64(n-1)*partSize, p) {
+				_ = c.AbortMultipartUpload(ctx, bucket, key, uploadID)
+				opts.Resume.Delete(resumeKey)
+				uploadID, existing = "", nil
+				break
+			}
+		}
+	}
+	if uploadID == "" {
+		uploadID, err = c.CreateMultipartUpload(ctx, bucket, key, opts.PutOptions)
+		if err != nil {
+			return nil, fmt.Errorf("error starting multipart upload: %w", err)
+		}
+		if opts.Resume != nil {
+			opts.Resume.Set(resumeKey, uploadID)
+		}
+	}
+
+	parts := make([]Part, 0, partCount)
+	var uploaded atomic.Int64
+	var pending []int
+	for n := 1; n <= partCount; n++ {
+		if p, ok := existing[n]; ok {
+			parts = append(parts, p)
+			uploaded.Add(p.Size)
+			continue
+		}
+		pending = append(pending, n)
+	}
+	resumed := len(parts)
+	report(uploaded.Load())
+
+	pool := pond.NewResultPool[Part](concurrency)
+	group := pool.NewGroup()
+	for _, n := range pending {
+		n := n
+		group.SubmitErr(func() (Part, error) {
+			length := partLength(n)
+			section := io.NewSectionReader(r, int64(n-1)*partSize, length)
+			etag, err := c.UploadPart(ctx, bucket, key, uploadID, n, section, length)
+			if err != nil {
+				return Part{}, fmt.Errorf("error uploading part %d: %w", n, err)
+			}
+			report(uploaded.Add(length))
+			return Part{PartNumber: n, ETag: etag, Size: length}, nil
+		})
+	}
+	newParts, err := group.Wait()
+	pool.StopAndWait()
+	if err != nil {
+		c.abortUnresumable(ctx, bucket, key, uploadID, opts.Resume)
+		return nil, err
+	}
+
+	parts = append(parts, newParts...)
+	sort.Slice(parts, func(i, j int) bool { return parts[i].PartNumber < parts[j].PartNumber })
+
+	etag, err := c.CompleteMultipartUpload(ctx, bucket, key, uploadID, parts)
+	if err != nil {
+		c.abortUnresumable(ctx, bucket, key, uploadID, opts.Resume)
+		return nil, fmt.Errorf("error completing multipart upload: %w", err)
+	}
+	if opts.Resume != nil {
+		opts.Resume.Delete(resumeKey)
+	}
+	return &UploadResult{ETag: etag, Multipart: true, Parts: len(parts), ResumedFrom: resumed}, nil
+}
+
+// abortUnresumable aborts a failed upload that no later run can resume, so
+// its parts are not kept and billed until the bucket's lifecycle removes them.
+func (c *Client) abortUnresumable(ctx context.Context, bucket, key, uploadID string, store ResumeStore) {
+	if store != nil {
+		return
+	}
+	_ = c.AbortMultipartUpload(context.WithoutCancel(ctx), bucket, key, uploadID)
+}
+
+// uploadResumeKey identifies an upload by everything that shapes the final
+// object, so a resumed upload never mixes data or headers of another run.
+func uploadResumeKey(bucket, key string, size, partSize int64, opts UploadOptions) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\x00%s\x00%d\x00%d\x00%s\x00%s", bucket, key, size, partSize, opts.ContentType, opts.Fingerprint)
+	for _, k := range slices.Sorted(maps.Keys(opts.Metadata)) {
+		fmt.Fprintf(h, "\x00%s=%s", k, opts.Metadata[k])
+	}
+	return "s3_upload:" + hex.EncodeToString(h.Sum(nil))
+}
+
+// findResumableUpload returns the upload recorded under resumeKey and its
+// parts, or no upload when none is recorded or it no longer exists.
+func (c *Client) findResumableUpload(ctx context.Context, bucket, key string, store ResumeStore, resumeKey string) (string, map[int]Part, error) {
+	if store == nil {
+		return "", nil, nil
+	}
+	uploadID, ok := store.Get(resumeKey)
+	if !ok {
+		return "", nil, nil
+	}
+
+	parts, err := c.ListParts(ctx, bucket, key, uploadID)
+	if err != nil {
+		if IsNotFound(err) {
+			store.Delete(resumeKey)
+			return "", nil, nil
+		}
+		return "", nil, fmt.Errorf("error listing uploaded parts: %w", err)
+	}
+	existing := make(map[int]Part, len(parts))
+	for _, p := range parts {
+		existing[p.PartNumber] = p
+	}
+	return uploadID, existing, nil
+}
+
+// partMatches reports whether an uploaded part holds the local bytes at
+// offset, comparing its ETag with their MD5.
+func partMatches(r io.ReaderAt, offset int64, p Part) bool {
+	h := md5.New()
+	if _, err := io.Copy(h, io.NewSectionReader(r, offset, p.Size)); err != nil {
+		return false
+	}
+	return hex.EncodeToString(h.Sum(nil)) == p.ETag
+}
@@ -0,0 +1,131 @@
+package s3
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+)
+
+type memResumeStore struct {
+	mu      sync.Mutex
+	uploads map[string]string
+}
+
+func (s *memResumeStore) Get(key string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id, ok := s.uploads[key]
+	return id, ok
+}
+
+func (s *memResumeStore) Set(key, uploadID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.uploads[key] = uploadID
+}
+
+func (s *memResumeStore) Delete(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.uploads, key)
+}
+
+// testData returns three full parts and a partial fourth.
+func testData() []byte {
+	data := make([]byte, 3*MinPartSize+1024)
+	for i := range data {
+		data[i] = byte(i % 251)
+	}
+	return data
+}
+
+func uploadOptions(store ResumeStore) UploadOptions {
+	return UploadOptions{PartSize: MinPartSize, Concurrency: 1, Resume: store, Fingerprint: "local:1"}
+}
+
+func TestUploadResumesFailedUpload(t *testing.T) {
+	fake, c := newFakeS3(t)
+	store := &memResumeStore{uploads: map[string]string{}}
+	data := testData()
+
+	fake.failPart = 3
+	if _, err := c.Upload(t.Context(), "bucket", "big.bin", bytes.NewReader(data), int64(len(data)), uploadOptions(store)); err == nil {
+		t.Fatal("expected the injected part failure")
+	}
+	if len(fake.aborted) != 0 || len(fake.uploads) != 1 || len(store.uploads) != 1 {
+		t.Fatalf("failed upload should be kept for resuming: aborted %v, uploads %d, records %d", fake.aborted, len(fake.uploads), len(store.uploads))
+	}
+
+	fake.failPart = 0
+	result, err := c.Upload(t.Context(), "bucket", "big.bin", bytes.NewReader(data), int64(len(data)), uploadOptions(store))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.ResumedFrom < 2 || result.Parts != 4 {
+		t.Errorf("result = %+v, want parts 1 and 2 reused", result)
+	}
+	if !bytes.Equal(fake.objects["bucket/big.bin"], data) {
+		t.Error("object does not match the uploaded data")
+	}
+	if len(store.uploads) != 0 {
+		t.Errorf("resume record kept after completing: %v", store.uploads)
+	}
+}
+
+func TestUploadRestartsWhenLocalDataChanged(t *testing.T) {
+	fake, c := newFakeS3(t)
+	store := &memResumeStore{uploads: map[string]string{}}
+	data := testData()
+
+	fake.failPart = 3
+	if _, err := c.Upload(t.Context(), "bucket", "big.bin", bytes.NewReader(data), int64(len(data)), uploadOptions(store)); err == nil {
+		t.Fatal("expected the injected part failure")
+	}
+	stale := store.uploads[uploadResumeKey("bucket", "big.bin", int64(len(data)), MinPartSize, uploadOptions(store))]
+	if stale == "" {
+		t.Fatal("no resume record for the failed upload")
+	}
+
+	// Same size and fingerprint, different bytes in an uploaded part.
+	changed := bytes.Clone(data)
+	changed[10]++
+	fake.failPart = 0
+	result, err := c.Upload(t.Context(), "bucket", "big.bin", bytes.NewReader(changed), int64(len(changed)), uploadOptions(store))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.ResumedFrom != 0 {
+		t.Errorf("resumed %d stale parts", result.ResumedFrom)
+	}
+	if len(fake.aborted) != 1 || fake.aborted[0] != stale {
+		t.Errorf("aborted = %v, want the stale upload %s", fake.aborted, stale)
+	}
+	if !bytes.Equal(fake.objects["bucket/big.bin"], changed) {
+		t.Error("object does not match the changed data")
+	}
+}
+
+func TestUploadAbortsWithoutResume(t *testing.T) {
+	fake, c := newFakeS3(t)
+	data := testData()
+
+	fake.failPart = 2
+	if _, err := c.Upload(t.Context(), "bucket", "big.bin", bytes.NewReader(data), int64(len(data)), uploadOptions(nil)); err == nil {
+		t.Fatal("expected the injected part failure")
+	}
+	if len(fake.aborted) != 1 || len(fake.uploads) != 0 {
+		t.Errorf("failed upload left behind: aborted %v, uploads %d", fake.aborted, len(fake.uploads))
+	}
+}
+
+func TestUploadSmallObject(t *testing.T) {
+	fake, c := newFakeS3(t)
+	data := []byte("hello")
+	result, err := c.Upload(t.Context(), "bucket", "small.txt", bytes.NewReader(data), int64(len(data)), uploadOptions(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Multipart || result.ETag != etagOf(data) || !bytes.Equal(fake.objects["bucket/small.txt"], data) {
+		t.Errorf("result = %+v", result)
+	}
+}
@@ -100,7 +100,15 @@ func (b *Builder) Display() {
 	}
 
 	if b.footerSuccess != "" {
-		fmt.Println(ui.Success(b.footerSuccess))
+		footer := ui.Success(b.footerSuccess)
+		if age, refreshing, ok := executor.CachedAge(); ok {
+			marker := fmt.Sprintf("(cached %s ago)", ui.FormatAge(age))
+			if refreshing {
+				marker = fmt.Sprintf("(cached %s ago, refreshing)", ui.FormatAge(age))
+			}
+			footer += " " + ui.Muted(marker)
+		}
+		fmt.Println(footer)
 	}
 }
 